@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
+	// zona waktu lokasi cinema tetap bisa dibaca di image tanpa tzdata
+	_ "time/tzdata"
 
 	"github.com/Darari17/be-tickitz/internal/configs"
+	"github.com/Darari17/be-tickitz/internal/repos"
 	"github.com/Darari17/be-tickitz/internal/routers"
+	"github.com/Darari17/be-tickitz/internal/workers"
 	"github.com/joho/godotenv"
 )
 
@@ -46,7 +51,19 @@ func main() {
 	log.Println("Redis Connected")
 	defer rdb.Close()
 
+	// payment providers
+	providers := configs.InitPayments()
+
+	// mailer
+	m := configs.InitMailer()
+
+	// worker order yang tidak dibayar
+	go workers.NewOrderExpiryWorker(repos.NewOrderRepo(db), repos.NewSeatEventRepo(rdb)).Run(context.Background())
+
+	// worker akun tamu yang tidak dipakai
+	go workers.NewGuestCleanupWorker(repos.NewAuthRepo(db)).Run(context.Background())
+
 	// router
-	router := routers.InitRouter(db, rdb)
+	router := routers.InitRouter(db, rdb, providers, m)
 	router.Run("localhost:8080")
 }
@@ -0,0 +1,12 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS subtotal,
+    DROP COLUMN IF EXISTS fee,
+    DROP COLUMN IF EXISTS total;
+
+ALTER TABLE order_seats DROP COLUMN IF EXISTS price;
+
+DROP TABLE IF EXISTS price_overrides;
+
+ALTER TABLE seats DROP COLUMN IF EXISTS seat_class;
+
+ALTER TABLE schedules DROP COLUMN IF EXISTS price;
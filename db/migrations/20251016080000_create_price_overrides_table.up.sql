@@ -0,0 +1,18 @@
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS price INT NOT NULL DEFAULT 0 CHECK (price >= 0);
+
+ALTER TABLE seats ADD COLUMN IF NOT EXISTS seat_class VARCHAR(20) NOT NULL DEFAULT 'regular';
+
+CREATE TABLE IF NOT EXISTS price_overrides (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    cinemas_id INT REFERENCES cinemas(id) ON DELETE CASCADE,
+    seat_class VARCHAR(20),
+    price INT NOT NULL CHECK (price >= 0),
+    CHECK (cinemas_id IS NOT NULL OR seat_class IS NOT NULL)
+);
+
+ALTER TABLE order_seats ADD COLUMN IF NOT EXISTS price INT NOT NULL DEFAULT 0;
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS subtotal INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS fee INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS total INT NOT NULL DEFAULT 0;
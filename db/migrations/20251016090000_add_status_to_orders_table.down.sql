@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS orders_schedules_status_idx;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS paid_at,
+    DROP COLUMN IF EXISTS used_at,
+    DROP COLUMN IF EXISTS expired_at,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS refunded_at;
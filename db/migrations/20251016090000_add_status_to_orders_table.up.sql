@@ -0,0 +1,15 @@
+-- order yang sudah ada dianggap sudah dibayar
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'paid'
+        CHECK (status IN ('pending', 'paid', 'used', 'expired', 'cancelled', 'refunded')),
+    ADD COLUMN IF NOT EXISTS paid_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS used_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS expired_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS refunded_at TIMESTAMP;
+
+UPDATE orders SET paid_at = created_at WHERE status = 'paid' AND paid_at IS NULL;
+
+ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'pending';
+
+CREATE INDEX IF NOT EXISTS orders_schedules_status_idx ON orders (schedules_id, status);
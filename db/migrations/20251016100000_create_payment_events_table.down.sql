@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS payment_events;
+
+DROP INDEX IF EXISTS orders_payment_reference_idx;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS payment_provider,
+    DROP COLUMN IF EXISTS payment_reference,
+    DROP COLUMN IF EXISTS payment_url,
+    DROP COLUMN IF EXISTS payment_instructions;
+
+ALTER TABLE payment_methods DROP COLUMN IF EXISTS provider;
@@ -0,0 +1,20 @@
+ALTER TABLE payment_methods ADD COLUMN IF NOT EXISTS provider VARCHAR(50) NOT NULL DEFAULT 'local';
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS payment_provider VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS payment_reference VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS payment_url TEXT,
+    ADD COLUMN IF NOT EXISTS payment_instructions TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS orders_payment_reference_idx ON orders (payment_provider, payment_reference);
+
+CREATE TABLE IF NOT EXISTS payment_events (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    provider VARCHAR(50) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    orders_id INT REFERENCES orders(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (provider, event_id)
+);
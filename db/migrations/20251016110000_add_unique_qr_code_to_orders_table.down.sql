@@ -0,0 +1 @@
+DROP INDEX IF EXISTS orders_qr_code_idx;
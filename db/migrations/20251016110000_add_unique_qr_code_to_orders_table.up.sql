@@ -0,0 +1,7 @@
+-- kode lama (QR-<unix>) bisa bentrok dan tidak ditandatangani,
+-- token baru dibuat ulang saat QR order tersebut diminta
+UPDATE orders SET qr_code = 'LEGACY-' || id WHERE qr_code NOT LIKE 'TKT1.%';
+
+ALTER TABLE orders ALTER COLUMN qr_code TYPE TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS orders_qr_code_idx ON orders (qr_code);
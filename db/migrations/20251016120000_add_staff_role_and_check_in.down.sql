@@ -0,0 +1,4 @@
+ALTER TABLE order_seats DROP COLUMN IF EXISTS checked_in_at;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'user', 'general'));
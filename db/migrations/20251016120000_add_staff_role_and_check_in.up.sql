@@ -0,0 +1,4 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check CHECK (role IN ('admin', 'user', 'general', 'staff'));
+
+ALTER TABLE order_seats ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP;
@@ -0,0 +1,3 @@
+ALTER TABLE profile ALTER COLUMN point DROP NOT NULL;
+
+DROP TABLE IF EXISTS points_ledger;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS points_ledger (
+    id SERIAL PRIMARY KEY,
+    users_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    orders_id INT REFERENCES orders(id) ON DELETE SET NULL,
+    delta INT NOT NULL,
+    balance INT NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS points_ledger_users_id_idx ON points_ledger (users_id, created_at DESC);
+
+-- satu order hanya bisa dapat poin sekali dan dibatalkan sekali
+CREATE UNIQUE INDEX IF NOT EXISTS points_ledger_order_reason_key ON points_ledger (orders_id, reason) WHERE orders_id IS NOT NULL;
+
+-- saldo yang sudah ada dicatat sebagai saldo awal supaya riwayat cocok dengan profile.point
+INSERT INTO points_ledger (users_id, delta, balance, reason)
+SELECT user_id, point, point, 'opening_balance' FROM profile WHERE COALESCE(point, 0) <> 0;
+
+UPDATE profile SET point = 0 WHERE point IS NULL;
+ALTER TABLE profile ALTER COLUMN point SET NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS points_redeemed;
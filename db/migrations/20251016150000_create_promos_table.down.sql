@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS orders_promos_id_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code;
+ALTER TABLE orders DROP COLUMN IF EXISTS promos_id;
+
+DROP TABLE IF EXISTS promo_cinemas;
+DROP TABLE IF EXISTS promo_movies;
+DROP TABLE IF EXISTS promos;
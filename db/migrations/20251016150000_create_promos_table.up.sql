@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS promos (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    description TEXT,
+    discount_type VARCHAR(10) NOT NULL CHECK (discount_type IN ('percent', 'fixed')),
+    discount_value INT NOT NULL CHECK (discount_value > 0),
+    max_discount INT,
+    min_tickets INT NOT NULL DEFAULT 1,
+    valid_from TIMESTAMPTZ,
+    valid_until TIMESTAMPTZ,
+    usage_limit INT,
+    per_user_limit INT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS promos_code_key ON promos (UPPER(code));
+
+-- kalau tidak ada baris untuk sebuah promo, promo berlaku untuk semua movie / cinema
+CREATE TABLE IF NOT EXISTS promo_movies (
+    promos_id INT NOT NULL REFERENCES promos(id) ON DELETE CASCADE,
+    movies_id INT NOT NULL REFERENCES movies(id) ON DELETE CASCADE,
+    PRIMARY KEY (promos_id, movies_id)
+);
+
+CREATE TABLE IF NOT EXISTS promo_cinemas (
+    promos_id INT NOT NULL REFERENCES promos(id) ON DELETE CASCADE,
+    cinemas_id INT NOT NULL REFERENCES cinemas(id) ON DELETE CASCADE,
+    PRIMARY KEY (promos_id, cinemas_id)
+);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promos_id INT REFERENCES promos(id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code VARCHAR(50);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_discount INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS orders_promos_id_idx ON orders (promos_id, users_id) WHERE promos_id IS NOT NULL;
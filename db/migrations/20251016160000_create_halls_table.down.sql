@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS seats_halls_id_grid_key;
+DROP INDEX IF EXISTS seats_halls_id_seat_code_key;
+
+ALTER TABLE schedules DROP COLUMN IF EXISTS halls_id;
+
+ALTER TABLE seats
+    DROP COLUMN IF EXISTS seat_type,
+    DROP COLUMN IF EXISTS grid_col,
+    DROP COLUMN IF EXISTS grid_row,
+    DROP COLUMN IF EXISTS halls_id;
+
+DROP TABLE IF EXISTS halls;
@@ -0,0 +1,68 @@
+CREATE TABLE IF NOT EXISTS halls (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    cinemas_id INT NOT NULL REFERENCES cinemas(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    row_count INT NOT NULL CHECK (row_count > 0),
+    column_count INT NOT NULL CHECK (column_count > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    UNIQUE (cinemas_id, name)
+);
+
+ALTER TABLE seats
+    ADD COLUMN IF NOT EXISTS halls_id INT REFERENCES halls(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS grid_row INT,
+    ADD COLUMN IF NOT EXISTS grid_col INT,
+    ADD COLUMN IF NOT EXISTS seat_type VARCHAR(20) NOT NULL DEFAULT 'standard';
+
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS halls_id INT REFERENCES halls(id);
+
+-- kursi global lama (A1, B5, ...) dijadikan layout studio pertama di setiap cinema
+WITH global_seats AS (
+    SELECT id, seat_code, seat_class,
+           ASCII(UPPER(SUBSTRING(seat_code FROM '^[A-Za-z]'))) - 64 AS grid_row,
+           COALESCE(SUBSTRING(seat_code FROM '[0-9]+')::int, 1) AS grid_col
+    FROM seats
+    WHERE halls_id IS NULL
+)
+INSERT INTO halls (cinemas_id, name, row_count, column_count)
+SELECT c.id, 'Studio 1', GREATEST(MAX(gs.grid_row), 1), GREATEST(MAX(gs.grid_col), 1)
+FROM cinemas c
+LEFT JOIN global_seats gs ON TRUE
+GROUP BY c.id
+ON CONFLICT (cinemas_id, name) DO NOTHING;
+
+INSERT INTO seats (seat_code, seat_class, halls_id, grid_row, grid_col)
+SELECT gs.seat_code, gs.seat_class, h.id,
+       ASCII(UPPER(SUBSTRING(gs.seat_code FROM '^[A-Za-z]'))) - 64,
+       COALESCE(SUBSTRING(gs.seat_code FROM '[0-9]+')::int, 1)
+FROM seats gs
+JOIN halls h ON h.name = 'Studio 1'
+WHERE gs.halls_id IS NULL;
+
+UPDATE schedules s
+SET halls_id = h.id
+FROM halls h
+WHERE s.halls_id IS NULL AND h.cinemas_id = s.cinemas_id AND h.name = 'Studio 1';
+
+-- kursi yang sudah terjual dipindah ke kursi dengan kode yang sama di studio schedule-nya
+UPDATE order_seats os
+SET seats_id = hs.id
+FROM orders o, schedules s, seats gs, seats hs
+WHERE o.id = os.orders_id
+  AND s.id = o.schedules_id
+  AND gs.id = os.seats_id
+  AND gs.halls_id IS NULL
+  AND hs.halls_id = s.halls_id
+  AND hs.seat_code = gs.seat_code;
+
+DELETE FROM seats WHERE halls_id IS NULL;
+
+ALTER TABLE seats
+    ALTER COLUMN halls_id SET NOT NULL,
+    ALTER COLUMN grid_row SET NOT NULL,
+    ALTER COLUMN grid_col SET NOT NULL;
+ALTER TABLE schedules ALTER COLUMN halls_id SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS seats_halls_id_seat_code_key ON seats (halls_id, seat_code);
+CREATE UNIQUE INDEX IF NOT EXISTS seats_halls_id_grid_key ON seats (halls_id, grid_row, grid_col);
@@ -0,0 +1,4 @@
+ALTER TABLE seats ALTER COLUMN seat_type SET DEFAULT 'standard';
+UPDATE seats SET seat_type = 'standard' WHERE seat_type = 'regular';
+
+ALTER TABLE seats DROP COLUMN IF EXISTS is_blocked;
@@ -0,0 +1,5 @@
+ALTER TABLE seats ADD COLUMN IF NOT EXISTS is_blocked BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- tipe kursi biasa disamakan dengan kelas harga "regular"
+UPDATE seats SET seat_type = 'regular' WHERE seat_type = 'standard';
+ALTER TABLE seats ALTER COLUMN seat_type SET DEFAULT 'regular';
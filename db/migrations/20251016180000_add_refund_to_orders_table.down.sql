@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS refund_reason,
+    DROP COLUMN IF EXISTS refund_amount;
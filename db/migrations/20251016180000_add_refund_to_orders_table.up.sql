@@ -0,0 +1,3 @@
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS refund_amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS refund_reason TEXT;
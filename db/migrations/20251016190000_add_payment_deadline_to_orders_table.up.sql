@@ -0,0 +1,7 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_deadline TIMESTAMPTZ;
+
+UPDATE orders SET payment_deadline = created_at + INTERVAL '15 minutes'
+WHERE status = 'pending' AND payment_deadline IS NULL;
+
+-- dipakai worker untuk mencari order pending yang lewat batas bayar
+CREATE INDEX IF NOT EXISTS idx_orders_pending_deadline ON orders (payment_deadline) WHERE status = 'pending';
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS order_transfers (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    orders_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    new_orders_id INT REFERENCES orders(id) ON DELETE SET NULL,
+    from_users_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_users_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    responded_at TIMESTAMP
+);
+
+-- satu order hanya boleh punya satu transfer yang menunggu jawaban
+CREATE UNIQUE INDEX IF NOT EXISTS order_transfers_pending_idx ON order_transfers (orders_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS order_transfers_to_users_idx ON order_transfers (to_users_id);
+CREATE INDEX IF NOT EXISTS order_transfers_from_users_idx ON order_transfers (from_users_id);
+
+CREATE TABLE IF NOT EXISTS order_transfer_seats (
+    order_transfers_id INT NOT NULL REFERENCES order_transfers(id) ON DELETE CASCADE,
+    seats_id INT NOT NULL REFERENCES seats(id),
+    PRIMARY KEY (order_transfers_id, seats_id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS schedules_halls_date_idx;
+ALTER TABLE schedules DROP COLUMN IF EXISTS cancelled_at;
@@ -0,0 +1,5 @@
+-- schedule yang dibatalkan tetap disimpan supaya order lama masih bisa dilacak,
+-- tapi tidak bisa dipesan lagi
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS schedules_halls_date_idx ON schedules (halls_id, date) WHERE cancelled_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS schedules_starts_at_idx;
+DROP INDEX IF EXISTS schedules_halls_starts_at_idx;
+ALTER TABLE schedules DROP COLUMN IF EXISTS starts_at;
+ALTER TABLE locations DROP COLUMN IF EXISTS timezone;
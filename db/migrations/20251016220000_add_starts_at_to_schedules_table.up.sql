@@ -0,0 +1,22 @@
+-- jam di tabel times adalah jam lokal cinema, zona waktunya disimpan per lokasi (nama IANA)
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta';
+
+ALTER TABLE schedules ADD COLUMN IF NOT EXISTS starts_at TIMESTAMPTZ;
+
+-- isi jam mulai schedule lama dari tanggal dan jam tayang di zona waktu lokasinya
+UPDATE schedules s
+SET starts_at = (s.date + trim(t.time)::time) AT TIME ZONE l.timezone
+FROM times t, locations l
+WHERE t.id = s.times_id AND l.id = s.locations_id AND s.starts_at IS NULL
+  AND trim(t.time) ~* '^(([01]?[0-9]|2[0-3]):[0-5][0-9](:[0-5][0-9])?|(0?[1-9]|1[0-2]):[0-5][0-9] ?[ap]m)$';
+
+-- jam tayang yang tidak bisa dibaca dianggap mulai tengah malam supaya tidak dianggap belum lewat
+UPDATE schedules s
+SET starts_at = s.date::timestamp AT TIME ZONE l.timezone
+FROM locations l
+WHERE l.id = s.locations_id AND s.starts_at IS NULL;
+
+ALTER TABLE schedules ALTER COLUMN starts_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS schedules_halls_starts_at_idx ON schedules (halls_id, starts_at) WHERE cancelled_at IS NULL;
+CREATE INDEX IF NOT EXISTS schedules_starts_at_idx ON schedules (starts_at) WHERE cancelled_at IS NULL;
@@ -0,0 +1,18 @@
+-- setiap pengembalian dana yang harus dikirim lewat provider dicatat di sini dulu,
+-- refund yang gagal tetap tersimpan sebagai pending supaya bisa diulang admin
+CREATE TABLE IF NOT EXISTS refunds (
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    orders_id INT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    provider VARCHAR(50),
+    payment_reference VARCHAR(255),
+    amount INT NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded')),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    refunded_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS refunds_orders_id_idx ON refunds (orders_id);
+CREATE INDEX IF NOT EXISTS refunds_pending_idx ON refunds (created_at) WHERE status = 'pending';
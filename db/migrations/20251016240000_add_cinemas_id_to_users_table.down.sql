@@ -0,0 +1,2 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_cinemas_id_staff_check;
+ALTER TABLE users DROP COLUMN IF EXISTS cinemas_id;
@@ -0,0 +1,5 @@
+-- staff hanya boleh check-in tiket di cinema tempat dia ditugaskan, diatur admin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS cinemas_id INT REFERENCES cinemas(id) ON DELETE SET NULL;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_cinemas_id_staff_check;
+ALTER TABLE users ADD CONSTRAINT users_cinemas_id_staff_check CHECK (cinemas_id IS NULL OR role = 'staff');
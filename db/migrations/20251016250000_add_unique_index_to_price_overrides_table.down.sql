@@ -0,0 +1 @@
+DROP INDEX IF EXISTS price_overrides_cinema_class_key;
@@ -0,0 +1,9 @@
+-- satu override per kombinasi cinema + kelas kursi, duplikat lama disisakan yang terbaru
+DELETE FROM price_overrides p
+USING price_overrides newer
+WHERE COALESCE(newer.cinemas_id, 0) = COALESCE(p.cinemas_id, 0)
+  AND COALESCE(newer.seat_class, '') = COALESCE(p.seat_class, '')
+  AND newer.id > p.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS price_overrides_cinema_class_key
+    ON price_overrides ((COALESCE(cinemas_id, 0)), (COALESCE(seat_class, '')));
@@ -0,0 +1,9 @@
+ALTER TABLE promos ALTER COLUMN id DROP IDENTITY IF EXISTS;
+CREATE SEQUENCE IF NOT EXISTS promos_id_seq OWNED BY promos.id;
+SELECT setval('promos_id_seq', COALESCE((SELECT MAX(id) FROM promos), 0) + 1, false);
+ALTER TABLE promos ALTER COLUMN id SET DEFAULT nextval('promos_id_seq');
+
+ALTER TABLE points_ledger ALTER COLUMN id DROP IDENTITY IF EXISTS;
+CREATE SEQUENCE IF NOT EXISTS points_ledger_id_seq OWNED BY points_ledger.id;
+SELECT setval('points_ledger_id_seq', COALESCE((SELECT MAX(id) FROM points_ledger), 0) + 1, false);
+ALTER TABLE points_ledger ALTER COLUMN id SET DEFAULT nextval('points_ledger_id_seq');
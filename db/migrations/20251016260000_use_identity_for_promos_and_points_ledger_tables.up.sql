@@ -0,0 +1,20 @@
+-- samakan promos dan points_ledger dengan tabel lain yang memakai identity, database yang sudah identity dilewati
+DO $$
+DECLARE
+    tbl TEXT;
+BEGIN
+    FOREACH tbl IN ARRAY ARRAY['promos', 'points_ledger'] LOOP
+        IF NOT EXISTS (
+            SELECT 1 FROM information_schema.columns
+            WHERE table_name = tbl AND column_name = 'id' AND is_identity = 'YES'
+        ) THEN
+            EXECUTE format('ALTER TABLE %I ALTER COLUMN id DROP DEFAULT', tbl);
+            EXECUTE format('DROP SEQUENCE IF EXISTS %I', tbl || '_id_seq');
+            EXECUTE format('ALTER TABLE %I ALTER COLUMN id ADD GENERATED ALWAYS AS IDENTITY', tbl);
+            EXECUTE format(
+                'SELECT setval(pg_get_serial_sequence(%L, ''id''), COALESCE((SELECT MAX(id) FROM %I), 0) + 1, false)',
+                tbl, tbl
+            );
+        END IF;
+    END LOOP;
+END $$;
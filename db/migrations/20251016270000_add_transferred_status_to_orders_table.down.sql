@@ -0,0 +1,7 @@
+UPDATE orders SET status = 'paid' WHERE status = 'transferred';
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('pending', 'paid', 'used', 'expired', 'cancelled', 'refunded'));
+
+ALTER TABLE orders DROP COLUMN IF EXISTS transferred_at;
@@ -0,0 +1,12 @@
+-- order yang seluruh kursinya sudah ditransfer ditutup dengan status transferred
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('pending', 'paid', 'used', 'expired', 'cancelled', 'refunded', 'transferred'));
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS transferred_at TIMESTAMP;
+
+-- order lunas yang kursinya sudah habis ditransfer sebelum status ini ada
+UPDATE orders o SET status = 'transferred', transferred_at = NOW()
+WHERE o.status = 'paid'
+  AND EXISTS (SELECT 1 FROM order_transfers t WHERE t.orders_id = o.id AND t.status = 'accepted')
+  AND NOT EXISTS (SELECT 1 FROM order_seats os WHERE os.orders_id = o.id);
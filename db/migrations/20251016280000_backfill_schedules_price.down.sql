@@ -0,0 +1,2 @@
+-- harga hasil backfill tidak dikembalikan ke 0
+ALTER TABLE schedules ALTER COLUMN price SET DEFAULT 0;
@@ -0,0 +1,21 @@
+-- schedule lama dibuat sebelum ada harga sehingga terisi 0 dari default kolom.
+-- harga diambil dari override cinema (tanpa kelas kursi), kalau tidak ada dari schedule
+-- terakhir untuk movie dan cinema yang sama yang sudah punya harga
+UPDATE schedules s SET price = po.price
+FROM price_overrides po
+WHERE s.price = 0 AND po.cinemas_id = s.cinemas_id AND po.seat_class IS NULL;
+
+UPDATE schedules s SET price = (
+    SELECT p.price FROM schedules p
+    WHERE p.movies_id = s.movies_id AND p.cinemas_id = s.cinemas_id AND p.price > 0
+    ORDER BY p.date DESC, p.id DESC
+    LIMIT 1
+)
+WHERE s.price = 0
+  AND EXISTS (
+    SELECT 1 FROM schedules p
+    WHERE p.movies_id = s.movies_id AND p.cinemas_id = s.cinemas_id AND p.price > 0
+  );
+
+-- schedule baru wajib menyebutkan harga
+ALTER TABLE schedules ALTER COLUMN price DROP DEFAULT;
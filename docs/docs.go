@@ -15,28 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/movies": {
+        "/admin/halls": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all movies for admin management",
+                "description": "Retrieve cinema halls, optionally filtered by cinema",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Get all movies (admin)",
+                "summary": "Get halls",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cinema ID",
+                        "name": "cinema_id",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Movies retrieved successfully",
+                        "description": "Halls retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                    "$ref": "#/definitions/dtos.Response"
                                 },
                                 {
                                     "type": "object",
@@ -44,7 +52,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Movie"
+                                                "$ref": "#/definitions/models.Hall"
                                             }
                                         }
                                     }
@@ -53,9 +61,9 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to fetch halls",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     }
                 }
@@ -66,9 +74,9 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new movie with poster \u0026 backdrop upload",
+                "description": "Create a hall for a cinema from a row-by-row layout. S = regular, V = vip, C = couple, W = wheelchair, _ = aisle or gap. Rows are lettered from A and seats numbered from the left, skipping gaps.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -76,94 +84,31 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Create a new movie",
+                "summary": "Create hall",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Movie title",
-                        "name": "title",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Movie overview",
-                        "name": "overview",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Movie director",
-                        "name": "director_name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Movie duration",
-                        "name": "duration",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Release date (YYYY-MM-DD)",
-                        "name": "release_date",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "Movie popularity",
-                        "name": "popularity",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Poster image",
-                        "name": "poster",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Backdrop image",
-                        "name": "backdrop",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Genre names (e.g. Action,Drama or genres=Action\u0026genres=Drama)",
-                        "name": "genres",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Cast names (e.g. Tom Holland,Zendaya or casts=Tom Holland\u0026casts=Zendaya)",
-                        "name": "casts",
-                        "in": "formData"
+                        "description": "Hall data",
+                        "name": "hall",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HallRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Movie created successfully",
+                        "description": "Hall created successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                    "$ref": "#/definitions/dtos.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Movie"
+                                            "$ref": "#/definitions/models.Hall"
                                         }
                                     }
                                 }
@@ -171,39 +116,51 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request data or layout",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Cinema not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Hall name already used",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to create hall",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     }
                 }
             }
         },
-        "/admin/movies/{id}": {
+        "/admin/halls/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific movie by ID for admin management",
+                "description": "Retrieve a hall with its seat grid and layout",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Get movie by ID (admin)",
+                "summary": "Get hall by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Hall ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -211,17 +168,17 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movie retrieved successfully",
+                        "description": "Hall retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                    "$ref": "#/definitions/dtos.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Movie"
+                                            "$ref": "#/definitions/models.Hall"
                                         }
                                     }
                                 }
@@ -229,15 +186,15 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Movie not found",
+                        "description": "Hall not found",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to fetch hall",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     }
                 }
@@ -248,18 +205,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft delete a movie by ID",
+                "description": "Delete a hall and its seats. Halls that already have schedules cannot be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Delete a movie",
+                "summary": "Delete hall",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Hall ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -267,15 +224,27 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movie deleted successfully",
+                        "description": "Hall deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/dtos.SuccessResponse"
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Hall not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Hall is still used",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to delete hall",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     }
                 }
@@ -286,9 +255,9 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update movie with optional poster \u0026 backdrop upload",
+                "description": "Rename a hall and/or replace its layout. The layout cannot be replaced once any of its seats has been ordered.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
@@ -296,97 +265,38 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Update movie",
+                "summary": "Update hall",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Hall ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Movie title",
-                        "name": "title",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Movie overview",
-                        "name": "overview",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Movie director",
-                        "name": "director_name",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Movie duration",
-                        "name": "duration",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Release date (YYYY-MM-DD)",
-                        "name": "release_date",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Movie popularity",
-                        "name": "popularity",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Poster image",
-                        "name": "poster",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Backdrop image",
-                        "name": "backdrop",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Genre names (e.g. Action,Drama or genres=Action\u0026genres=Drama)",
-                        "name": "genres",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Cast names (e.g. Robert Downey Jr,Chris Evans or casts=Robert Downey Jr\u0026casts=Chris Evans)",
-                        "name": "casts",
-                        "in": "formData"
+                        "description": "Hall data",
+                        "name": "hall",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateHallRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movie updated successfully",
+                        "description": "Hall updated successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                    "$ref": "#/definitions/dtos.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Movie"
+                                            "$ref": "#/definitions/models.Hall"
                                         }
                                     }
                                 }
@@ -394,29 +304,40 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request data or layout",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "404": {
-                        "description": "Movie not found",
+                        "description": "Hall not found",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Hall name already used or seats already ordered",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to update hall",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     }
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Authenticate user and return JWT token",
+        "/admin/halls/{id}/blocked-seats": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the list of blocked seats in a hall, e.g. broken seats or seats kept for house use. Blocked seats show up as blocked in the seat map and cannot be held or ordered. Send an empty list to unblock every seat.",
                 "consumes": [
                     "application/json"
                 ],
@@ -424,33 +345,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "User login",
+                "summary": "Set blocked seats",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.AuthRequest"
+                        "type": "integer",
+                        "description": "Hall ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Blocked seat codes",
+                        "name": "seats",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BlockedSeatsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "Blocked seats updated successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                    "$ref": "#/definitions/dtos.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.AuthResponse"
+                                            "$ref": "#/definitions/models.Hall"
                                         }
                                     }
                                 }
@@ -458,57 +386,41 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request data or unknown seats",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
-                    "401": {
-                        "description": "Invalid email or password",
+                    "404": {
+                        "description": "Hall not found",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to update blocked seats",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     }
                 }
             }
         },
-        "/movies": {
+        "/admin/movies": {
             "get": {
-                "description": "Retrieve paginated list of all movies with optional search and genre filters",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all movies for admin management",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
-                ],
-                "summary": "Get all movies with filters",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search query for movie title",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Genre name filter",
-                        "name": "genre",
-                        "in": "query"
-                    }
+                    "Admin"
                 ],
+                "summary": "Get all movies (admin)",
                 "responses": {
                     "200": {
                         "description": "Movies retrieved successfully",
@@ -532,36 +444,107 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch movies",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/movies/popular": {
-            "get": {
-                "description": "Retrieve paginated list of popular movies",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new movie with poster \u0026 backdrop upload",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Admin"
                 ],
-                "summary": "Get popular movies",
+                "summary": "Create a new movie",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Movie title",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Movie overview",
+                        "name": "overview",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Movie director",
+                        "name": "director_name",
+                        "in": "formData"
+                    },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "description": "Movie duration",
+                        "name": "duration",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release date (YYYY-MM-DD)",
+                        "name": "release_date",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Movie popularity",
+                        "name": "popularity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Poster image",
+                        "name": "poster",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Backdrop image",
+                        "name": "backdrop",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Genre names (e.g. Action,Drama or genres=Action\u0026genres=Drama)",
+                        "name": "genres",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Cast names (e.g. Tom Holland,Zendaya or casts=Tom Holland\u0026casts=Zendaya)",
+                        "name": "casts",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Popular movies retrieved successfully",
+                    "201": {
+                        "description": "Movie created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -571,68 +554,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Movie"
-                                            }
+                                            "$ref": "#/definitions/models.Movie"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Failed to fetch popular movies",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/movies/upcoming": {
-            "get": {
-                "description": "Retrieve paginated list of upcoming movies",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Movies"
-                ],
-                "summary": "Get upcoming movies",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Upcoming movies retrieved successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Movie"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
                     },
                     "500": {
-                        "description": "Failed to fetch upcoming movies",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
@@ -640,16 +576,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/movies/{id}": {
+        "/admin/movies/{id}": {
             "get": {
-                "description": "Retrieve detailed information about a specific movie",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific movie by ID for admin management",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Admin"
                 ],
-                "summary": "Get movie detail",
+                "summary": "Get movie by ID (admin)",
                 "parameters": [
                     {
                         "type": "integer",
@@ -661,7 +602,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Movie detail retrieved successfully",
+                        "description": "Movie retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -678,12 +619,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid movie ID",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Movie not found",
                         "schema": {
@@ -691,162 +626,224 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/orders": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new movie ticket order",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Soft delete a movie by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Create a new order",
+                "summary": "Delete a movie",
                 "parameters": [
                     {
-                        "description": "Order creation data",
-                        "name": "order",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateOrderRequest"
-                        }
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Order created successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dtos.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.Order"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request payload or seat codes",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "Movie deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "$ref": "#/definitions/dtos.SuccessResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create order",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/orders/history": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve order history for the authenticated user",
+                "description": "Update movie with optional poster \u0026 backdrop upload. Schedules sent in the form are added to the movie, existing schedules are managed through /admin/schedules.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
+                ],
+                "summary": "Update movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Movie title",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Movie overview",
+                        "name": "overview",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Movie director",
+                        "name": "director_name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie duration",
+                        "name": "duration",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release date (YYYY-MM-DD)",
+                        "name": "release_date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Movie popularity",
+                        "name": "popularity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Poster image",
+                        "name": "poster",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Backdrop image",
+                        "name": "backdrop",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Genre names (e.g. Action,Drama or genres=Action\u0026genres=Drama)",
+                        "name": "genres",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Cast names (e.g. Robert Downey Jr,Chris Evans or casts=Robert Downey Jr\u0026casts=Chris Evans)",
+                        "name": "casts",
+                        "in": "formData"
+                    }
                 ],
-                "summary": "Get user order history",
                 "responses": {
                     "200": {
-                        "description": "Order history retrieved successfully",
+                        "description": "Movie updated successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.Response"
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.OrderDetail"
-                                            }
+                                            "$ref": "#/definitions/models.Movie"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch order history",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/orders/schedules": {
-            "get": {
+        "/admin/orders/{id}/refund": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all schedules for a specific movie",
+                "description": "Cancel any order as admin and release its seats. Paid orders are refunded following the refund policy unless an explicit amount is given, e.g. a full refund when the show itself is cancelled. An amount of 0 cancels without refund. A refund the payment provider rejects stays pending in /admin/refunds.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Get movie schedules",
+                "summary": "Refund order",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
-                        "name": "movie_id",
-                        "in": "query",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Refund data",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RefundOrderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Schedules retrieved successfully",
+                        "description": "Order refunded successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -856,10 +853,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.Schedule"
-                                            }
+                                            "$ref": "#/definitions/models.Order"
                                         }
                                     }
                                 }
@@ -867,13 +861,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid movie_id",
+                        "description": "Invalid order ID, request payload or amount",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order status or refund policy does not allow a refund",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch schedules",
+                        "description": "Failed to refund order",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
@@ -881,37 +887,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/seats": {
+        "/admin/prices": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve available seats for a specific schedule",
+                "description": "Retrieve ticket price overrides per cinema and/or seat class. Each override is an absolute ticket price that replaces the schedule price.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
-                ],
-                "summary": "Get available seats",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "schedule_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Admin"
                 ],
+                "summary": "Get price overrides",
                 "responses": {
                     "200": {
-                        "description": "Available seats retrieved successfully",
+                        "description": "Price overrides retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.Response"
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
                                 },
                                 {
                                     "type": "object",
@@ -919,7 +916,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/models.Seat"
+                                                "$ref": "#/definitions/models.PriceOverride"
                                             }
                                         }
                                     }
@@ -927,58 +924,55 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid schedule_id",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.Response"
-                        }
-                    },
                     "500": {
-                        "description": "Failed to fetch seats",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/orders/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information about a specific transaction",
+                "description": "Set an absolute ticket price for a cinema, a seat class, or both. The override replaces the schedule price, it is not added to it. When several overrides match a seat, the most specific one wins: cinema and seat class, then seat class only, then cinema only. Seats without a matching override use the schedule price.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Get transaction detail",
+                "summary": "Create price override",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Price override",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PriceOverrideRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Transaction detail retrieved successfully",
+                    "201": {
+                        "description": "Price override created successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.Response"
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.OrderDetail"
+                                            "$ref": "#/definitions/models.PriceOverride"
                                         }
                                     }
                                 }
@@ -986,144 +980,172 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID",
+                        "description": "Invalid request",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Order not found",
+                    "409": {
+                        "description": "Price override for this cinema and seat class already exists",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "$ref": "#/definitions/dtos.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/profile": {
+        "/admin/prices/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a ticket price override by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete price override",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Price override ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price override deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/promos": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve profile information for the authenticated user",
+                "description": "Retrieve all promo codes with their current usage",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Admin"
                 ],
-                "summary": "Get user profile",
+                "summary": "Get promos",
                 "responses": {
                     "200": {
-                        "description": "Profile retrieved successfully",
+                        "description": "Promos retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                    "$ref": "#/definitions/dtos.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dtos.ProfileResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Promo"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Profile not found",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
-                        }
-                    },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to fetch promos",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update profile information for the authenticated user",
+                "description": "Create a percentage or fixed promo code with optional validity dates, usage caps and movie/cinema restrictions",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Admin"
                 ],
-                "summary": "Update user profile",
+                "summary": "Create promo",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "First name",
-                        "name": "firstname",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Last name",
-                        "name": "lastname",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Phone number",
-                        "name": "phone_number",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "file",
-                        "description": "Avatar image",
-                        "name": "avatar",
-                        "in": "formData"
+                        "description": "Promo data",
+                        "name": "promo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PromoRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Profile updated successfully",
+                    "201": {
+                        "description": "Promo created successfully",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Promo"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request data",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Promo code already exists",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to update profile",
+                        "description": "Failed to create promo",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
@@ -1131,78 +1153,114 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile/change-password": {
-            "patch": {
+        "/admin/promos/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Change password using old, new, and confirm password",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Retrieve a promo code by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Profile"
+                    "Admin"
                 ],
-                "summary": "Change user password",
+                "summary": "Get promo by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Old password",
-                        "name": "old_password",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "New password",
-                        "name": "new_password",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Confirm password",
-                        "name": "confirm_password",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Promo ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Password changed successfully",
+                        "description": "Promo retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Promo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Promo not found",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body or password mismatch",
+                    "500": {
+                        "description": "Failed to fetch promo",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a promo code. Orders that already used the code keep their discount.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete promo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promo deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized or invalid old password",
+                    "404": {
+                        "description": "Promo not found",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to update password",
+                        "description": "Failed to delete promo",
                         "schema": {
                             "$ref": "#/definitions/dtos.Response"
                         }
                     }
                 }
-            }
-        },
-        "/register": {
-            "post": {
-                "description": "Register a new user account",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a promo code. Only the fields sent are changed, an empty movie_ids or cinema_ids list removes the restriction. Optional limits are removed by listing them in clear, e.g. [\"max_discount\",\"valid_until\"].",
                 "consumes": [
                     "application/json"
                 ],
@@ -1210,411 +1268,5470 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "User registration",
+                "summary": "Update promo",
                 "parameters": [
                     {
-                        "description": "Registration data",
-                        "name": "user",
+                        "type": "integer",
+                        "description": "Promo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promo data",
+                        "name": "promo",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.AuthRequest"
+                            "$ref": "#/definitions/dtos.UpdatePromoRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Registration successful",
+                    "200": {
+                        "description": "Promo updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/dtos.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Promo"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request data",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Promo not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "409": {
-                        "description": "Email already exists",
+                        "description": "Promo code already exists",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to update promo",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ErrorResponse"
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/refunds": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve refunds sent through payment providers, newest first. Pending refunds still have to be paid back, for example a payment that arrived after its order expired or a provider call that failed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get refunds",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded"
+                        ],
+                        "type": "string",
+                        "description": "pending or succeeded, empty for both",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refunds retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Refund"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch refunds",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/refunds/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a pending refund to the payment provider again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Retry refund",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Refund ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund sent successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Refund"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Pending refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retry refund",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Payment provider refund failed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Refund"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve schedules with the number of sold seats, optionally filtered by movie, cinema, location, hall, date range and status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get schedules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cinema ID",
+                        "name": "cinema_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "location_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Hall ID",
+                        "name": "hall_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "active or cancelled, empty for both",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedules retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleDetail"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch schedules",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a single schedule for a movie. Without hall_id the movie plays in the cinema's first hall. The showtime plus the movie duration and the cleaning buffer may not overlap other schedules in the same hall.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule data",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Schedule created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, unknown movie, cinema, location, time or hall",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Hall not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
                         }
+                    },
+                    "409": {
+                        "description": "Showtime overlaps other schedules in the hall",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create schedule",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/schedules/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create several schedules at once. Either every schedule is created or none is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create schedules in bulk",
+                "parameters": [
+                    {
+                        "description": "Schedules",
+                        "name": "schedules",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BulkScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Schedules created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Schedule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, unknown movie, cinema, location, time or hall",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Hall not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Showtime overlaps other schedules in the hall or in the same request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create schedules",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/schedules/recurring": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Expand a recurrence rule into schedules, one for every date in the range that falls on one of the weekdays, for every cinema and time slot. Everything is saved in one transaction. With dry_run the generated showtimes are only previewed. Showtimes that overlap other schedules in the hall are flagged with their conflicts, and if any is flagged nothing is saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create recurring schedules",
+                "parameters": [
+                    {
+                        "description": "Recurrence rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RecurringScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedules previewed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.RecurringScheduleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Schedules created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.RecurringScheduleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, unknown movie, cinema, location, time or hall",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Hall not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Some showtimes overlap other schedules",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.RecurringScheduleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create schedules",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a schedule with the number of sold seats",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get schedule by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch schedule",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a schedule that has never been ordered. Schedules with orders have to be cancelled instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule already has orders",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete schedule",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a schedule. Only the fields sent are changed. The hall cannot be changed once seats have been sold, and changing the cinema without hall_id moves the schedule to the new cinema's first hall.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule data",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ScheduleDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, unknown cinema, location, time or hall",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule or hall not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule is cancelled, already has sold seats or overlaps other schedules in the hall",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update schedule",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/schedules/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a schedule so it can no longer be booked. Seat holds are dropped and the seats are released to live seat pickers. Unpaid orders are cancelled, paid orders are refunded in full through their payment provider. Refunds the provider rejects stay pending in /admin/refunds so they can be retried.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Cancel schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.CancelScheduleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule is already cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to cancel schedule",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/staff/{id}/cinema": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the cinema where a staff account checks in tickets. A registered user account is promoted to staff at the same time and gets staff access after logging in again. Admin and guest accounts cannot be assigned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Assign staff to a cinema",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cinema",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AssignStaffCinemaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staff assigned successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or unknown cinema",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found or cannot be staff",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to assign staff",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest/claim": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move the orders made during a guest checkout into the logged in account using the token from the order link",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guest"
+                ],
+                "summary": "Claim guest orders",
+                "parameters": [
+                    {
+                        "description": "Order link token",
+                        "name": "claim",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ClaimGuestOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Guest orders claimed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.RegisterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or order link",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order is not a guest order",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to claim guest orders",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest/orders": {
+            "get": {
+                "description": "Retrieve an order through the secure link sent to the guest's email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guest"
+                ],
+                "summary": "Get guest order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from the order link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OrderDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired order link",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest/token": {
+            "post": {
+                "description": "Get a short-lived token for booking without an account. The token can hold seats and create orders under /orders with just a name, email and phone number. A link to the order is sent to that email.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guest"
+                ],
+                "summary": "Start guest checkout",
+                "responses": {
+                    "201": {
+                        "description": "Guest token created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Too many guest tokens requested from this address",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create guest token",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticate user and return JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuthRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies": {
+            "get": {
+                "description": "Retrieve paginated list of all movies with optional search and genre filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get all movies with filters",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query for movie title",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Genre name filter",
+                        "name": "genre",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movies retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Movie"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch movies",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/popular": {
+            "get": {
+                "description": "Retrieve paginated list of popular movies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get popular movies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Popular movies retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Movie"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch popular movies",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/upcoming": {
+            "get": {
+                "description": "Retrieve paginated list of upcoming movies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get upcoming movies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upcoming movies retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Movie"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch upcoming movies",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/movies/{id}": {
+            "get": {
+                "description": "Retrieve detailed information about a specific movie",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get movie detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Movie detail retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Movie"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Movie not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new movie ticket order. A promo code and loyalty points can be applied as discounts on the ticket price. The response contains the payment provider's instructions or redirect URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create a new order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique key per checkout attempt. Retries with the same key and payload return the original response, a different payload with the same key is rejected with 422.",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Order creation data",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, seat codes, promo code or redeemed points",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Showtime already started or cancelled, seats are not held by the user or already booked, or a request with the same Idempotency-Key is still being processed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reused with a different payload",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create order",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Payment provider unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve order history for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get user order history",
+                "responses": {
+                    "200": {
+                        "description": "Order history retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.OrderDetail"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch order history",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/holds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Temporarily hold seats for a schedule before checkout. Holds expire automatically. A user can hold at most SEAT_HOLD_MAX seats (10 by default) per schedule at a time, counting seats already held.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Hold seats",
+                "parameters": [
+                    {
+                        "description": "Seats to hold",
+                        "name": "hold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeatHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Seats held successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.SeatHoldResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or seat codes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Showtime already started or cancelled, seats already held or sold, or too many seats held",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to hold seats",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Release seats held by the user so other users can pick them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Release seat holds",
+                "parameters": [
+                    {
+                        "description": "Held seats to release",
+                        "name": "hold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeatHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seat holds released successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or seat codes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Showtime already started or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to release seat holds",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Extend the expiry of seats currently held by the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Extend seat holds",
+                "parameters": [
+                    {
+                        "description": "Held seats to extend",
+                        "name": "hold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SeatHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seat holds extended successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.SeatHoldResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or seat codes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Showtime already started or cancelled, or seats are not held by the user",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to extend seat holds",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/quote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Preview the price of an order with an optional promo code and redeemed points without creating it. The quote does not reserve the promo quota or points, checkout validates them again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Quote an order",
+                "parameters": [
+                    {
+                        "description": "Order to quote",
+                        "name": "quote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.QuoteOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order quoted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.OrderQuoteResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, seat codes, promo code or redeemed points",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Showtime already started or cancelled, or seats already booked",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to quote order",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all schedules for a specific movie",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get movie schedules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedules retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Schedule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movie_id",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch schedules",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/seats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve available seats for a specific schedule. Seats held by other users are excluded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get available seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Available seats retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Seat"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule_id",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Showtime already started or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch seats",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/seats/map": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the full seat grid of the schedule's hall. Every row has one cell per column, null cells are aisles or gaps. Each seat carries its type (regular, vip, couple, wheelchair), price class, price and status (available, held, sold, blocked).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get seat map",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seat map retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SeatMap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule_id",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Showtime already started or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch seat map",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve order transfers sent or received by the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get transfers",
+                "responses": {
+                    "200": {
+                        "description": "Transfers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.OrderTransfer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch transfers",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/transfers/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept a transfer sent to the authenticated user. The seats move to a new order owned by the recipient and the ticket QR codes of both orders are re-issued, so the old QR code stops working. When every seat of the order is transferred, the sender's order is closed with status transferred and no longer has a ticket.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Accept transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer accepted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OrderTransfer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transfer ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer already answered or order no longer transferable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to accept transfer",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/transfers/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Withdraw a pending transfer sent by the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OrderTransfer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transfer ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer already answered",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to cancel transfer",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/transfers/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Decline a transfer sent to the authenticated user, the seats stay with the sender",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Decline transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer declined successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OrderTransfer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transfer ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Transfer already answered",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to decline transfer",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed information about a specific transaction. Only the owner of the order or an admin can see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get transaction detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction detail retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OrderDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an order owned by the authenticated user and release its seats. Unpaid orders are simply cancelled. Paid orders are refunded following the refund policy (REFUND_POLICY, by default 100% until 24h before the show and 50% until 2h before) and cannot be cancelled after the last refund window. The refund is recorded before it is sent to the payment provider, a refund the provider rejects stays pending in /admin/refunds.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation reason",
+                        "name": "cancel",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CancelOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID or request payload",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order status or refund policy does not allow cancellation",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to cancel order",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a pending order as paid (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Confirm order payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment confirmed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order status does not allow this action",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to confirm payment",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/qr": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render the signed ticket token of a paid order as a PNG QR code",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get ticket QR code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not paid, its seats were transferred or ticket token is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate QR code",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/qr/reissue": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a new signed ticket token for a paid order and return it as a PNG QR code. The previous QR code stops working. Only the order's owner or an admin can reissue a ticket.",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Reissue ticket QR code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not paid or its seats were transferred",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reissue ticket",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/ticket.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download a printable PDF ticket of a paid order. Only the order's owner can download it.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Download e-ticket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF ticket",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not paid, its seats were transferred or ticket token is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to generate ticket",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Offer a paid order, or some of its seats, to another registered user by email. The recipient must have a regular user account, guest, staff and admin accounts cannot receive tickets. The seats move once the recipient accepts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Transfer order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipient and seats, empty seat_codes transfers the whole order",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Transfer created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.OrderTransfer"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, recipient or seats",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not paid or already has a pending transfer",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create transfer",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook/{provider}": {
+            "post": {
+                "description": "Receive a signed payment notification from a provider and update the order. Repeated events are acknowledged without being processed again. A payment for an order that already expired or was cancelled is refunded, and if the refund fails it stays pending under /admin/refunds.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Payment provider webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "local",
+                        "description": "Payment provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hex HMAC-SHA256 of the request body",
+                        "name": "X-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payments.WebhookEvent"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Order"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown provider or order",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process webhook",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve profile information for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.ProfileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update profile information for the authenticated user",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First name",
+                        "name": "firstname",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last name",
+                        "name": "lastname",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Phone number",
+                        "name": "phone_number",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Avatar image",
+                        "name": "avatar",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update profile",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/change-password": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change password using old, new, and confirm password",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Old password",
+                        "name": "old_password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "New password",
+                        "name": "new_password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Confirm password",
+                        "name": "confirm_password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or password mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid old password",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update password",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile/points": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the point balance and the dated history of point changes for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Get loyalty points",
+                "responses": {
+                    "200": {
+                        "description": "Points retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PointsHistory"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Register a new user account. Send the token from a guest order link as guest_token to move the orders made during that guest checkout into the new account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User registration",
+                "parameters": [
+                    {
+                        "description": "Registration data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Registration successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dtos.RegisterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or guest token",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules": {
+            "get": {
+                "description": "Find showtimes by movie, location, cinema and date range. Results are grouped by date, then cinema, and every showtime includes the number of seats still for sale. Seats that are only held are counted as remaining. Showtimes that have already started are never returned. starts_at and ends_at are in the cinema's local timezone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Browse showtimes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "location_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cinema ID",
+                        "name": "cinema_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First date (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last date (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Showtimes retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ShowtimeDate"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch showtimes",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{id}/seats/stream": {
+            "get": {
+                "description": "Server-Sent Events stream of seat status changes for a schedule. The first event is \"snapshot\" with the current seat map, followed by \"held\", \"released\" and \"sold\" events carrying the affected seat ids. Events from every server instance are delivered.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Stream seat availability",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Seat events",
+                        "schema": {
+                            "$ref": "#/definitions/models.SeatEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to open seat stream",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/staff/checkin": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Validate a scanned ticket QR token at the cinema door and mark its seats as checked in. A ticket can only be checked in once. Staff can only check in tickets for the cinema an admin assigned them to. Admins pass the cinema in cinema_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Check in a ticket",
+                "parameters": [
+                    {
+                        "description": "Scanned token, cinema_id is only read for admins",
+                        "name": "checkin",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CheckInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CheckInResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Staff is not assigned to a cinema",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Ticket rejected, see data.reason",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.CheckInResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to check in ticket",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.AssignStaffCinemaRequest": {
+            "type": "object",
+            "required": [
+                "cinema_id"
+            ],
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dtos.AuthRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@mail.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Password123"
+                }
+            }
+        },
+        "dtos.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.BlockedSeatsRequest": {
+            "type": "object",
+            "properties": {
+                "seat_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2"
+                    ]
+                }
+            }
+        },
+        "dtos.BulkScheduleRequest": {
+            "type": "object",
+            "required": [
+                "schedules"
+            ],
+            "properties": {
+                "schedules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.CreateScheduleRequest"
+                    }
+                }
+            }
+        },
+        "dtos.CancelOrderRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Cannot make it to the show"
+                }
+            }
+        },
+        "dtos.CancelScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled_orders": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12,
+                        15
+                    ]
+                },
+                "refunded_orders": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        9,
+                        10
+                    ]
+                },
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Refund"
+                    }
+                },
+                "schedule": {
+                    "$ref": "#/definitions/models.ScheduleDetail"
+                }
+            }
+        },
+        "dtos.CheckInRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "token": {
+                    "type": "string",
+                    "example": "TKT1.MTI6..."
+                }
+            }
+        },
+        "dtos.ClaimGuestOrderRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "ORD1.MTI6MTc2MzI1MDAwMA.c2lnbmF0dXJl"
+                }
+            }
+        },
+        "dtos.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "fullname",
+                "payment_id",
+                "phone",
+                "schedule_id",
+                "seat_codes"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "farid@example.com"
+                },
+                "fullname": {
+                    "type": "string",
+                    "example": "Farid Darari"
+                },
+                "payment_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+628123456789"
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "NONTON50"
+                },
+                "redeem_points": {
+                    "description": "poin loyalty yang ditukar jadi potongan harga, 0 kalau tidak dipakai",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "seat_codes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"A1\"",
+                        "\"A2\"]"
+                    ]
+                }
+            }
+        },
+        "dtos.CreateScheduleRequest": {
+            "type": "object",
+            "required": [
+                "cinema_id",
+                "date",
+                "location_id",
+                "movie_id",
+                "price",
+                "time_id"
+            ],
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-12-01"
+                },
+                "hall_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "movie_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 50000
+                },
+                "time_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "error"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dtos.HallRequest": {
+            "type": "object",
+            "required": [
+                "cinema_id",
+                "layout",
+                "name"
+            ],
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "layout": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SSSS_SSSS",
+                        "SSSS_SSSS",
+                        "VVVV_VVVV",
+                        "CC______CC"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Studio 1"
+                }
+            }
+        },
+        "dtos.OrderQuoteResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "fee": {
+                    "type": "integer",
+                    "example": 5000
+                },
+                "points_redeemed": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "promo_code": {
+                    "type": "string",
+                    "example": "NONTON50"
+                },
+                "promo_discount": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Seat"
+                    }
+                },
+                "subtotal": {
+                    "type": "integer",
+                    "example": 100000
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 54000
+                }
+            }
+        },
+        "dtos.PriceOverrideRequest": {
+            "type": "object",
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 75000
+                },
+                "seat_class": {
+                    "type": "string",
+                    "example": "vip"
+                }
+            }
+        },
+        "dtos.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string",
+                    "example": "https://example.com/avatar.png"
+                },
+                "firstname": {
+                    "type": "string",
+                    "example": "Farid"
+                },
+                "lastname": {
+                    "type": "string",
+                    "example": "Darari"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "08123456789"
+                },
+                "point": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "dtos.PromoRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "discount_type",
+                "discount_value"
+            ],
+            "properties": {
+                "cinema_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "NONTON50"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Diskon 50% minimal 2 tiket"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "example": "percent"
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 50
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_discount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 50000
+                },
+                "min_tickets": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "movie_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "per_user_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 100
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2025-12-01T00:00:00+07:00"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59+07:00"
+                }
+            }
+        },
+        "dtos.QuoteOrderRequest": {
+            "type": "object",
+            "required": [
+                "schedule_id",
+                "seat_codes"
+            ],
+            "properties": {
+                "promo_code": {
+                    "type": "string",
+                    "example": "NONTON50"
+                },
+                "redeem_points": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 100
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "seat_codes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"A1\"",
+                        "\"A2\"]"
+                    ]
+                }
+            }
+        },
+        "dtos.RecurringCinemaRequest": {
+            "type": "object",
+            "required": [
+                "cinema_id",
+                "location_id",
+                "price"
+            ],
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "hall_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 50000
+                }
+            }
+        },
+        "dtos.RecurringScheduleRequest": {
+            "type": "object",
+            "required": [
+                "cinemas",
+                "end_date",
+                "movie_id",
+                "start_date",
+                "time_ids"
+            ],
+            "properties": {
+                "cinemas": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.RecurringCinemaRequest"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-31"
+                },
+                "movie_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-12-01"
+                },
+                "time_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        3
+                    ]
+                },
+                "weekdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "fri",
+                        "sat",
+                        "sun"
+                    ]
+                }
+            }
+        },
+        "dtos.RecurringScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SchedulePreview"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 26
+                }
+            }
+        },
+        "dtos.RefundOrderRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 54000
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Projector broken, show cancelled"
+                }
+            }
+        },
+        "dtos.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@mail.com"
+                },
+                "guest_token": {
+                    "description": "token dari link order tamu, order tamu tersebut dipindahkan ke akun baru",
+                    "type": "string",
+                    "example": "ORD1.MTI6MTc2MzI1MDAwMA.c2lnbmF0dXJl"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Password123"
+                }
+            }
+        },
+        "dtos.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "claimed_orders": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "request berhasil"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.SeatHoldRequest": {
+            "type": "object",
+            "required": [
+                "schedule_id",
+                "seat_codes"
+            ],
+            "properties": {
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "seat_codes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"A1\"",
+                        "\"A2\"]"
+                    ]
+                }
+            }
+        },
+        "dtos.SeatHoldResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-12-01T13:10:00Z"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "seat_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"A1\"",
+                        "\"A2\"]"
+                    ]
+                }
+            }
+        },
+        "dtos.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "get data success"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.TransferRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "friend@mail.com"
+                },
+                "seat_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"A2\"]"
+                    ]
+                }
+            }
+        },
+        "dtos.UpdateHallRequest": {
+            "type": "object",
+            "properties": {
+                "layout": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "SSSS_SSSS",
+                        "SSSS_SSSS",
+                        "VVVV_VVVV"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Studio 1"
+                }
+            }
+        },
+        "dtos.UpdatePromoRequest": {
+            "type": "object",
+            "properties": {
+                "cinema_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2
+                    ]
+                },
+                "clear": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "max_discount",
+                        "valid_until"
+                    ]
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "NONTON50"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Diskon 50% minimal 2 tiket"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ],
+                    "example": "percent"
+                },
+                "discount_value": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 50
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_discount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 50000
+                },
+                "min_tickets": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "movie_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "per_user_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "usage_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 100
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2025-12-01T00:00:00+07:00"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59+07:00"
+                }
+            }
+        },
+        "dtos.UpdateScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-12-02"
+                },
+                "hall_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 55000
+                },
+                "time_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.Cast": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CheckInReason": {
+            "type": "string",
+            "enum": [
+                "accepted",
+                "unknown_token",
+                "already_used",
+                "not_paid",
+                "wrong_cinema",
+                "wrong_day",
+                "no_seats"
+            ],
+            "x-enum-varnames": [
+                "CheckInAccepted",
+                "CheckInUnknownToken",
+                "CheckInAlreadyUsed",
+                "CheckInNotPaid",
+                "CheckInWrongCinema",
+                "CheckInWrongDay",
+                "CheckInNoSeats"
+            ]
+        },
+        "models.CheckInResult": {
+            "type": "object",
+            "properties": {
+                "accepted": {
+                    "type": "boolean"
+                },
+                "checked_in_at": {
+                    "type": "string"
+                },
+                "cinema_name": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "movie_title": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "$ref": "#/definitions/models.CheckInReason"
+                },
+                "seat_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CinemaShowtimes": {
+            "type": "object",
+            "properties": {
+                "cinema_id": {
+                    "type": "integer"
+                },
+                "cinema_name": {
+                    "type": "string"
+                },
+                "location_id": {
+                    "type": "integer"
+                },
+                "location_name": {
+                    "type": "string"
+                },
+                "showtimes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Showtime"
+                    }
+                }
+            }
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Hall": {
+            "type": "object",
+            "properties": {
+                "cinema_id": {
+                    "type": "integer"
+                },
+                "columns": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "layout": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Seat"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Movie": {
+            "type": "object",
+            "properties": {
+                "backdrop_path": {
+                    "type": "string"
+                },
+                "casts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Cast"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "director_name": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Genre"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overview": {
+                    "type": "string"
+                },
+                "popularity": {
+                    "type": "number"
+                },
+                "poster_path": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Order": {
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "fullname": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "payment_deadline": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "payment_instructions": {
+                    "type": "string"
+                },
+                "payment_provider": {
+                    "type": "string"
+                },
+                "payment_reference": {
+                    "type": "string"
+                },
+                "payment_url": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "points_redeemed": {
+                    "type": "integer"
+                },
+                "promo_code": {
+                    "type": "string"
+                },
+                "promo_discount": {
+                    "type": "integer"
+                },
+                "promo_id": {
+                    "type": "integer"
+                },
+                "refund_amount": {
+                    "type": "integer"
+                },
+                "refund_reason": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "integer"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Seat"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "transferred_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "used_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrderDetail": {
+            "type": "object",
+            "properties": {
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "cinema_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T21:45:00+07:00"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "integer"
+                },
+                "fullname": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "movie": {
+                    "$ref": "#/definitions/models.Movie"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "payment": {
+                    "type": "string"
+                },
+                "payment_deadline": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "payment_instructions": {
+                    "type": "string"
+                },
+                "payment_provider": {
+                    "type": "string"
+                },
+                "payment_reference": {
+                    "type": "string"
+                },
+                "payment_url": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "points_redeemed": {
+                    "type": "integer"
+                },
+                "promo_code": {
+                    "type": "string"
+                },
+                "promo_discount": {
+                    "type": "integer"
+                },
+                "promo_id": {
+                    "type": "integer"
+                },
+                "refund_amount": {
+                    "type": "integer"
+                },
+                "refund_reason": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "integer"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Seat"
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "dtos.AuthRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@mail.com"
                 },
-                "password": {
+                "starts_at": {
                     "type": "string",
-                    "example": "Password123"
+                    "example": "2025-12-01T19:30:00+07:00"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "transferred_at": {
+                    "type": "string"
+                },
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderTransfer"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "used_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.AuthResponse": {
+        "models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "paid",
+                "used",
+                "expired",
+                "cancelled",
+                "refunded",
+                "transferred"
+            ],
+            "x-enum-comments": {
+                "OrderTransferred": "seluruh kursinya sudah dipindah ke order penerima transfer"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "",
+                "",
+                "",
+                "seluruh kursinya sudah dipindah ke order penerima transfer"
+            ],
+            "x-enum-varnames": [
+                "OrderPending",
+                "OrderPaid",
+                "OrderUsed",
+                "OrderExpired",
+                "OrderCancelled",
+                "OrderRefunded",
+                "OrderTransferred"
+            ]
+        },
+        "models.OrderTransfer": {
             "type": "object",
             "properties": {
-                "token": {
+                "created_at": {
                     "type": "string"
                 },
-                "user_id": {
+                "from_email": {
+                    "type": "string"
+                },
+                "from_user_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_order_id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "responded_at": {
+                    "type": "string"
+                },
+                "seat_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/models.TransferStatus"
+                },
+                "to_email": {
+                    "type": "string"
+                },
+                "to_user_id": {
                     "type": "string"
                 }
             }
         },
-        "dtos.CreateOrderRequest": {
+        "models.PointsEntry": {
             "type": "object",
-            "required": [
-                "email",
-                "fullname",
-                "payment_id",
-                "phone",
-                "schedule_id",
-                "seat_codes"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "farid@example.com"
+                "balance": {
+                    "type": "integer"
                 },
-                "fullname": {
-                    "type": "string",
-                    "example": "Farid Darari"
+                "created_at": {
+                    "type": "string"
                 },
-                "payment_id": {
-                    "type": "integer",
-                    "example": 2
+                "delta": {
+                    "type": "integer"
                 },
-                "phone": {
-                    "type": "string",
-                    "example": "+628123456789"
+                "id": {
+                    "type": "integer"
                 },
-                "schedule_id": {
-                    "type": "integer",
-                    "example": 8
+                "order_id": {
+                    "type": "integer"
                 },
-                "seat_codes": {
+                "reason": {
+                    "$ref": "#/definitions/models.PointsReason"
+                }
+            }
+        },
+        "models.PointsHistory": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "history": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"A1\"",
-                        "\"A2\"]"
-                    ]
+                        "$ref": "#/definitions/models.PointsEntry"
+                    }
                 }
             }
         },
-        "dtos.ErrorResponse": {
+        "models.PointsReason": {
+            "type": "string",
+            "enum": [
+                "opening_balance",
+                "order_paid",
+                "order_reversed",
+                "order_redeemed",
+                "redeem_refunded"
+            ],
+            "x-enum-varnames": [
+                "PointsOpeningBalance",
+                "PointsEarned",
+                "PointsReversed",
+                "PointsRedeemed",
+                "PointsRedeemRefunded"
+            ]
+        },
+        "models.PriceOverride": {
+            "type": "object",
+            "properties": {
+                "cinema_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "seat_class": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Promo": {
             "type": "object",
             "properties": {
+                "cinema_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "code": {
-                    "type": "integer",
-                    "example": 400
+                    "type": "string"
                 },
-                "data": {},
-                "message": {
-                    "type": "string",
-                    "example": "error"
+                "created_at": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "description": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "$ref": "#/definitions/models.PromoType"
+                },
+                "discount_value": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_discount": {
+                    "type": "integer"
+                },
+                "min_tickets": {
+                    "type": "integer"
+                },
+                "movie_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "per_user_limit": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "usage_limit": {
+                    "type": "integer"
+                },
+                "used_count": {
+                    "type": "integer"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.ProfileResponse": {
+        "models.PromoType": {
+            "type": "string",
+            "enum": [
+                "percent",
+                "fixed"
+            ],
+            "x-enum-varnames": [
+                "PromoPercent",
+                "PromoFixed"
+            ]
+        },
+        "models.Refund": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "string",
-                    "example": "https://example.com/avatar.png"
+                "amount": {
+                    "type": "integer"
                 },
-                "firstname": {
-                    "type": "string",
-                    "example": "Farid"
+                "attempts": {
+                    "type": "integer"
                 },
-                "lastname": {
-                    "type": "string",
-                    "example": "Darari"
+                "created_at": {
+                    "type": "string"
                 },
-                "phone_number": {
-                    "type": "string",
-                    "example": "08123456789"
+                "id": {
+                    "type": "integer"
                 },
-                "point": {
-                    "type": "integer",
-                    "example": 100
+                "last_error": {
+                    "type": "string"
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "order_id": {
+                    "type": "integer"
+                },
+                "payment_reference": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "refunded_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.RefundStatus"
                 }
             }
         },
-        "dtos.Response": {
+        "models.RefundStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded"
+            ],
+            "x-enum-varnames": [
+                "RefundPending",
+                "RefundSucceeded"
+            ]
+        },
+        "models.Schedule": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 200
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "cinema_id": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T21:45:00+07:00"
+                },
+                "hall_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location_id": {
+                    "type": "integer"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
                 },
-                "data": {},
-                "message": {
+                "starts_at": {
                     "type": "string",
-                    "example": "request berhasil"
+                    "example": "2025-12-01T19:30:00+07:00"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "time_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dtos.SuccessResponse": {
+        "models.ScheduleConflict": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 200
+                "date": {
+                    "type": "string"
                 },
-                "data": {},
-                "message": {
-                    "type": "string",
-                    "example": "get data success"
+                "ends_at": {
+                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
-                }
-            }
-        },
-        "models.Cast": {
-            "type": "object",
-            "properties": {
-                "id": {
+                "hall_id": {
                     "type": "integer"
                 },
-                "name": {
+                "movie_id": {
+                    "type": "integer"
+                },
+                "movie_title": {
                     "type": "string"
-                }
-            }
-        },
-        "models.Genre": {
-            "type": "object",
-            "properties": {
-                "id": {
+                },
+                "schedule_id": {
                     "type": "integer"
                 },
-                "name": {
+                "starts_at": {
+                    "type": "string"
+                },
+                "time": {
                     "type": "string"
                 }
             }
         },
-        "models.Movie": {
+        "models.ScheduleDetail": {
             "type": "object",
             "properties": {
-                "backdrop_path": {
+                "cancelled_at": {
                     "type": "string"
                 },
-                "casts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Cast"
-                    }
+                "cinema_id": {
+                    "type": "integer"
                 },
-                "created_at": {
+                "cinema_name": {
                     "type": "string"
                 },
-                "deleted_at": {
+                "date": {
                     "type": "string"
                 },
-                "director_name": {
-                    "type": "string"
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T21:45:00+07:00"
                 },
-                "duration": {
+                "hall_id": {
                     "type": "integer"
                 },
-                "genres": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Genre"
-                    }
+                "hall_name": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "overview": {
+                "location_id": {
+                    "type": "integer"
+                },
+                "location_name": {
                     "type": "string"
                 },
-                "popularity": {
-                    "type": "number"
+                "movie_id": {
+                    "type": "integer"
                 },
-                "poster_path": {
+                "movie_title": {
                     "type": "string"
                 },
-                "release_date": {
-                    "type": "string"
+                "price": {
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string"
+                "sold_seats": {
+                    "type": "integer"
                 },
-                "updated_at": {
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-12-01T19:30:00+07:00"
+                },
+                "time": {
                     "type": "string"
+                },
+                "time_id": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Jakarta"
+                },
+                "total_seats": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Order": {
+        "models.SchedulePreview": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "cancelled_at": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "cinema_id": {
+                    "type": "integer"
                 },
-                "fullname": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScheduleConflict"
+                    }
+                },
+                "date": {
                     "type": "string"
                 },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T21:45:00+07:00"
+                },
+                "hall_id": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "payment_id": {
+                "location_id": {
                     "type": "integer"
                 },
-                "phone": {
+                "movie_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-12-01T19:30:00+07:00"
+                },
+                "time_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Seat": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_blocked": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "seat_class": {
                     "type": "string"
                 },
-                "qr_code": {
+                "seat_code": {
                     "type": "string"
                 },
+                "seat_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SeatEvent": {
+            "type": "object",
+            "properties": {
                 "schedule_id": {
                     "type": "integer"
                 },
-                "seats": {
+                "seat_ids": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Seat"
+                        "type": "integer"
                     }
                 },
-                "updated_at": {
-                    "type": "string"
+                "status": {
+                    "$ref": "#/definitions/models.SeatStatus"
                 },
-                "user_id": {
-                    "type": "string"
+                "type": {
+                    "$ref": "#/definitions/models.SeatEventType"
                 }
             }
         },
-        "models.OrderDetail": {
+        "models.SeatEventType": {
+            "type": "string",
+            "enum": [
+                "held",
+                "released",
+                "sold"
+            ],
+            "x-enum-varnames": [
+                "SeatEventHeld",
+                "SeatEventReleased",
+                "SeatEventSold"
+            ]
+        },
+        "models.SeatMap": {
             "type": "object",
             "properties": {
-                "cinema_name": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
+                "columns": {
+                    "type": "integer"
                 },
-                "date": {
-                    "type": "string"
+                "grid": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SeatMapRow"
+                    }
                 },
-                "email": {
-                    "type": "string"
+                "hall_id": {
+                    "type": "integer"
                 },
-                "fullname": {
+                "hall_name": {
                     "type": "string"
                 },
-                "id": {
+                "rows": {
                     "type": "integer"
                 },
-                "location": {
-                    "type": "string"
-                },
-                "movie": {
-                    "$ref": "#/definitions/models.Movie"
+                "schedule_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SeatMapRow": {
+            "type": "object",
+            "properties": {
+                "cells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SeatMapSeat"
+                    }
                 },
-                "payment": {
+                "label": {
                     "type": "string"
                 },
-                "payment_id": {
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SeatMapSeat": {
+            "type": "object",
+            "properties": {
+                "column": {
                     "type": "integer"
                 },
-                "phone": {
-                    "type": "string"
+                "held_by_you": {
+                    "type": "boolean"
                 },
-                "qr_code": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "schedule_id": {
+                "price": {
                     "type": "integer"
                 },
-                "seats": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Seat"
-                    }
+                "row": {
+                    "type": "integer"
                 },
-                "time": {
+                "seat_class": {
                     "type": "string"
                 },
-                "updated_at": {
+                "seat_code": {
                     "type": "string"
                 },
-                "user_id": {
+                "seat_type": {
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.SeatStatus"
                 }
             }
         },
-        "models.Schedule": {
+        "models.SeatStatus": {
+            "type": "string",
+            "enum": [
+                "available",
+                "held",
+                "sold",
+                "blocked"
+            ],
+            "x-enum-varnames": [
+                "SeatAvailable",
+                "SeatHeld",
+                "SeatSold",
+                "SeatBlocked"
+            ]
+        },
+        "models.Showtime": {
             "type": "object",
             "properties": {
-                "cinema_id": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2025-12-01T21:45:00+07:00"
+                },
+                "hall_id": {
                     "type": "integer"
                 },
-                "date": {
+                "hall_name": {
                     "type": "string"
                 },
-                "id": {
+                "movie_id": {
                     "type": "integer"
                 },
-                "location_id": {
+                "movie_title": {
+                    "type": "string"
+                },
+                "price": {
                     "type": "integer"
                 },
-                "movie_id": {
+                "schedule_id": {
+                    "type": "integer"
+                },
+                "seats_remaining": {
                     "type": "integer"
                 },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2025-12-01T19:30:00+07:00"
+                },
+                "time": {
+                    "type": "string"
+                },
                 "time_id": {
                     "type": "integer"
+                },
+                "total_seats": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Seat": {
+        "models.ShowtimeDate": {
             "type": "object",
             "properties": {
-                "id": {
+                "cinemas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CinemaShowtimes"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-12-01"
+                }
+            }
+        },
+        "models.TransferStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "accepted",
+                "declined",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "TransferPending",
+                "TransferAccepted",
+                "TransferDeclined",
+                "TransferCancelled"
+            ]
+        },
+        "payments.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "paid",
+                "failed",
+                "expired",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusPaid",
+                "StatusFailed",
+                "StatusExpired",
+                "StatusRefunded"
+            ]
+        },
+        "payments.WebhookEvent": {
+            "type": "object",
+            "properties": {
+                "amount": {
                     "type": "integer"
                 },
-                "seat_code": {
+                "event_id": {
+                    "type": "string"
+                },
+                "reference": {
                     "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/payments.Status"
                 }
             }
         }
@@ -0,0 +1,25 @@
+package configs
+
+import (
+	"os"
+
+	"github.com/Darari17/be-tickitz/internal/mailer"
+)
+
+func InitMailer() mailer.Mailer {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Tickitz <no-reply@tickitz.local>"
+	}
+
+	if os.Getenv("MAIL_DRIVER") == "smtp" {
+		return mailer.NewSMTPMailer(
+			os.Getenv("SMTP_HOST"),
+			os.Getenv("SMTP_PORT"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			from,
+		)
+	}
+	return mailer.NewLogMailer(from, os.Getenv("MAIL_DIR"))
+}
@@ -0,0 +1,13 @@
+package configs
+
+import (
+	"os"
+
+	"github.com/Darari17/be-tickitz/internal/payments"
+)
+
+func InitPayments() *payments.Registry {
+	return payments.NewRegistry(
+		payments.NewLocalProvider(os.Getenv("PAYMENT_LOCAL_SECRET")),
+	)
+}
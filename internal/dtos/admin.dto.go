@@ -3,13 +3,17 @@ package dtos
 import (
 	"mime/multipart"
 	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
 )
 
 type ScheduleRequest struct {
 	CinemaID   int    `json:"cinema_id" form:"cinema_id" example:"2"`
 	LocationID int    `json:"location_id" form:"location_id" example:"1"`
+	HallID     *int   `json:"hall_id" form:"hall_id" example:"3"`
 	Date       string `json:"date" form:"date" example:"2025-12-01"`
 	TimeIDs    []int  `json:"time_ids" form:"time_ids" example:"1"`
+	Price      int    `json:"price" form:"price" binding:"required,min=1" example:"50000"`
 }
 
 type CreateMovieRequest struct {
@@ -39,3 +43,121 @@ type UpdateMovieRequest struct {
 	Casts       []string              `json:"casts" form:"casts" example:"Robert Downey Jr,Chris Evans"`
 	Schedules   []ScheduleRequest     `json:"schedules" form:"schedules"`
 }
+
+type PriceOverrideRequest struct {
+	CinemaID  *int    `json:"cinema_id" example:"2"`
+	SeatClass *string `json:"seat_class" example:"vip"`
+	Price     int     `json:"price" binding:"min=0" example:"75000"`
+}
+
+type PromoRequest struct {
+	Code          string     `json:"code" binding:"required,max=50" example:"NONTON50"`
+	Description   *string    `json:"description" example:"Diskon 50% minimal 2 tiket"`
+	DiscountType  string     `json:"discount_type" binding:"required,oneof=percent fixed" example:"percent"`
+	DiscountValue int        `json:"discount_value" binding:"required,min=1" example:"50"`
+	MaxDiscount   *int       `json:"max_discount" binding:"omitempty,min=1" example:"50000"`
+	MinTickets    int        `json:"min_tickets" binding:"min=0" example:"2"`
+	ValidFrom     *time.Time `json:"valid_from" example:"2025-12-01T00:00:00+07:00"`
+	ValidUntil    *time.Time `json:"valid_until" example:"2025-12-31T23:59:59+07:00"`
+	UsageLimit    *int       `json:"usage_limit" binding:"omitempty,min=1" example:"100"`
+	PerUserLimit  *int       `json:"per_user_limit" binding:"omitempty,min=1" example:"1"`
+	IsActive      *bool      `json:"is_active" example:"true"`
+	MovieIDs      []int      `json:"movie_ids" example:"1,2"`
+	CinemaIDs     []int      `json:"cinema_ids" example:"2"`
+}
+
+// UpdatePromoRequest hanya mengubah field yang dikirim. Clear berisi kolom opsional yang dikosongkan (NULL),
+// kolom yang sama tidak boleh diisi dan dikosongkan sekaligus.
+type UpdatePromoRequest struct {
+	Code          *string    `json:"code" binding:"omitempty,max=50" example:"NONTON50"`
+	Description   *string    `json:"description" example:"Diskon 50% minimal 2 tiket"`
+	DiscountType  *string    `json:"discount_type" binding:"omitempty,oneof=percent fixed" example:"percent"`
+	DiscountValue *int       `json:"discount_value" binding:"omitempty,min=1" example:"50"`
+	MaxDiscount   *int       `json:"max_discount" binding:"omitempty,min=1" example:"50000"`
+	MinTickets    *int       `json:"min_tickets" binding:"omitempty,min=0" example:"2"`
+	ValidFrom     *time.Time `json:"valid_from" example:"2025-12-01T00:00:00+07:00"`
+	ValidUntil    *time.Time `json:"valid_until" example:"2025-12-31T23:59:59+07:00"`
+	UsageLimit    *int       `json:"usage_limit" binding:"omitempty,min=1" example:"100"`
+	PerUserLimit  *int       `json:"per_user_limit" binding:"omitempty,min=1" example:"1"`
+	IsActive      *bool      `json:"is_active" example:"false"`
+	MovieIDs      []int      `json:"movie_ids" example:"1,2"`
+	CinemaIDs     []int      `json:"cinema_ids" example:"2"`
+	Clear         []string   `json:"clear" binding:"omitempty,dive,oneof=description max_discount valid_from valid_until usage_limit per_user_limit" example:"max_discount,valid_until"`
+}
+
+// HallRequest membuat studio dari layout per baris, S = regular, V = vip,
+// C = couple, W = wheelchair, _ = lorong / celah tanpa kursi
+type HallRequest struct {
+	CinemaID int      `json:"cinema_id" binding:"required" example:"2"`
+	Name     string   `json:"name" binding:"required,max=50" example:"Studio 1"`
+	Layout   []string `json:"layout" binding:"required,min=1" example:"SSSS_SSSS,SSSS_SSSS,VVVV_VVVV,CC______CC"`
+}
+
+type UpdateHallRequest struct {
+	Name   *string  `json:"name" binding:"omitempty,max=50" example:"Studio 1"`
+	Layout []string `json:"layout" example:"SSSS_SSSS,SSSS_SSSS,VVVV_VVVV"`
+}
+
+// BlockedSeatsRequest berisi seluruh kursi yang diblokir, kosong berarti semua kursi dibuka
+type BlockedSeatsRequest struct {
+	SeatCodes []string `json:"seat_codes" example:"A1,A2"`
+}
+
+// CreateScheduleRequest membuat satu schedule, tanpa hall_id diputar di studio pertama cinema
+type CreateScheduleRequest struct {
+	MovieID    int    `json:"movie_id" binding:"required" example:"1"`
+	CinemaID   int    `json:"cinema_id" binding:"required" example:"2"`
+	LocationID int    `json:"location_id" binding:"required" example:"1"`
+	HallID     *int   `json:"hall_id" example:"3"`
+	Date       string `json:"date" binding:"required" example:"2025-12-01"`
+	TimeID     int    `json:"time_id" binding:"required" example:"1"`
+	Price      int    `json:"price" binding:"required,min=1" example:"50000"`
+}
+
+type BulkScheduleRequest struct {
+	Schedules []CreateScheduleRequest `json:"schedules" binding:"required,min=1,dive"`
+}
+
+type UpdateScheduleRequest struct {
+	CinemaID   *int    `json:"cinema_id" example:"2"`
+	LocationID *int    `json:"location_id" example:"1"`
+	HallID     *int    `json:"hall_id" example:"3"`
+	Date       *string `json:"date" example:"2025-12-02"`
+	TimeID     *int    `json:"time_id" example:"2"`
+	Price      *int    `json:"price" binding:"omitempty,min=1" example:"55000"`
+}
+
+// CancelScheduleResponse berisi order yang ikut dibatalkan, order lunas yang direfund
+// dan hasil refund ke payment provider
+type CancelScheduleResponse struct {
+	Schedule        *models.ScheduleDetail `json:"schedule"`
+	CancelledOrders []int                  `json:"cancelled_orders" example:"12,15"`
+	RefundedOrders  []int                  `json:"refunded_orders" example:"9,10"`
+	Refunds         []*models.Refund       `json:"refunds"`
+}
+
+type RecurringCinemaRequest struct {
+	CinemaID   int  `json:"cinema_id" binding:"required" example:"2"`
+	LocationID int  `json:"location_id" binding:"required" example:"1"`
+	HallID     *int `json:"hall_id" example:"3"`
+	Price      int  `json:"price" binding:"required,min=1" example:"50000"`
+}
+
+// RecurringScheduleRequest membuat schedule untuk setiap kombinasi tanggal, cinema dan jam tayang.
+// weekdays kosong berarti setiap hari di rentang tanggal.
+type RecurringScheduleRequest struct {
+	MovieID   int                      `json:"movie_id" binding:"required" example:"1"`
+	StartDate string                   `json:"start_date" binding:"required" example:"2025-12-01"`
+	EndDate   string                   `json:"end_date" binding:"required" example:"2025-12-31"`
+	Weekdays  []string                 `json:"weekdays" example:"fri,sat,sun"`
+	TimeIDs   []int                    `json:"time_ids" binding:"required,min=1" example:"1,3"`
+	Cinemas   []RecurringCinemaRequest `json:"cinemas" binding:"required,min=1,dive"`
+	DryRun    bool                     `json:"dry_run" example:"true"`
+}
+
+type RecurringScheduleResponse struct {
+	DryRun    bool                     `json:"dry_run"`
+	Total     int                      `json:"total" example:"26"`
+	Conflicts int                      `json:"conflicts" example:"0"`
+	Schedules []models.SchedulePreview `json:"schedules"`
+}
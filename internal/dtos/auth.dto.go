@@ -11,3 +11,19 @@ type AuthResponse struct {
 	UserID uuid.UUID `json:"user_id"`
 	Token  string    `json:"token"`
 }
+
+type RegisterRequest struct {
+	Email    string `json:"email" binding:"required,email" example:"user@mail.com"`
+	Password string `json:"password" binding:"required" example:"Password123"`
+	// token dari link order tamu, order tamu tersebut dipindahkan ke akun baru
+	GuestToken string `json:"guest_token" example:"ORD1.MTI6MTc2MzI1MDAwMA.c2lnbmF0dXJl"`
+}
+
+type RegisterResponse struct {
+	UserID        uuid.UUID `json:"user_id"`
+	ClaimedOrders int       `json:"claimed_orders"`
+}
+
+type ClaimGuestOrderRequest struct {
+	Token string `json:"token" binding:"required" example:"ORD1.MTI6MTc2MzI1MDAwMA.c2lnbmF0dXJl"`
+}
@@ -1,5 +1,11 @@
 package dtos
 
+import (
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+)
+
 type CreateOrderRequest struct {
 	ScheduleID int      `json:"schedule_id" binding:"required" example:"8"`
 	PaymentID  int      `json:"payment_id" binding:"required" example:"2"`
@@ -7,4 +13,63 @@ type CreateOrderRequest struct {
 	Email      string   `json:"email" binding:"required,email" example:"farid@example.com"`
 	Phone      string   `json:"phone" binding:"required" example:"+628123456789"`
 	SeatCodes  []string `json:"seat_codes" binding:"required,min=1" example:"[\"A1\",\"A2\"]"`
+	PromoCode  string   `json:"promo_code" example:"NONTON50"`
+	// poin loyalty yang ditukar jadi potongan harga, 0 kalau tidak dipakai
+	RedeemPoints int `json:"redeem_points" binding:"min=0" example:"100"`
+}
+
+type QuoteOrderRequest struct {
+	ScheduleID   int      `json:"schedule_id" binding:"required" example:"8"`
+	SeatCodes    []string `json:"seat_codes" binding:"required,min=1" example:"[\"A1\",\"A2\"]"`
+	PromoCode    string   `json:"promo_code" example:"NONTON50"`
+	RedeemPoints int      `json:"redeem_points" binding:"min=0" example:"100"`
+}
+
+type OrderQuoteResponse struct {
+	ScheduleID     int           `json:"schedule_id" example:"8"`
+	Seats          []models.Seat `json:"seats"`
+	Subtotal       int           `json:"subtotal" example:"100000"`
+	Fee            int           `json:"fee" example:"5000"`
+	PromoCode      *string       `json:"promo_code,omitempty" example:"NONTON50"`
+	PromoDiscount  int           `json:"promo_discount" example:"50000"`
+	PointsRedeemed int           `json:"points_redeemed" example:"100"`
+	Discount       int           `json:"discount" example:"1000"`
+	Total          int           `json:"total" example:"54000"`
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" binding:"max=255" example:"Cannot make it to the show"`
+}
+
+// RefundOrderRequest dipakai admin, amount kosong berarti mengikuti kebijakan refund
+type RefundOrderRequest struct {
+	Reason string `json:"reason" binding:"required,max=255" example:"Projector broken, show cancelled"`
+	Amount *int   `json:"amount" binding:"omitempty,min=0" example:"54000"`
+}
+
+// TransferRequest mengirim kursi order ke user lain, seat_codes kosong berarti seluruh order
+type TransferRequest struct {
+	Email     string   `json:"email" binding:"required,email" example:"friend@mail.com"`
+	SeatCodes []string `json:"seat_codes" example:"[\"A2\"]"`
+}
+
+type SeatHoldRequest struct {
+	ScheduleID int      `json:"schedule_id" binding:"required" example:"8"`
+	SeatCodes  []string `json:"seat_codes" binding:"required,min=1" example:"[\"A1\",\"A2\"]"`
+}
+
+type SeatHoldResponse struct {
+	ScheduleID int       `json:"schedule_id" example:"8"`
+	SeatCodes  []string  `json:"seat_codes" example:"[\"A1\",\"A2\"]"`
+	ExpiresAt  time.Time `json:"expires_at" example:"2025-12-01T13:10:00Z"`
+}
+
+// CheckInRequest memakai cinema tempat staff ditugaskan, cinema_id hanya dibaca untuk admin
+type CheckInRequest struct {
+	Token    string `json:"token" binding:"required" example:"TKT1.MTI6..."`
+	CinemaID int    `json:"cinema_id" example:"2"`
+}
+
+type AssignStaffCinemaRequest struct {
+	CinemaID int `json:"cinema_id" binding:"required" example:"2"`
 }
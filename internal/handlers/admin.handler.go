@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -80,19 +82,21 @@ func (h *AdminHandler) CreateMovie(ctx *gin.Context) {
 		movie.Backdrop = path
 	}
 
-	var schedules []map[string]interface{}
-	for _, s := range body.Schedules {
-		date, _ := time.Parse("2006-01-02", s.Date)
-		schedules = append(schedules, map[string]interface{}{
-			"date":        date,
-			"cinema_id":   s.CinemaID,
-			"location_id": s.LocationID,
-			"time_ids":    s.TimeIDs,
+	schedules, err := schedulesFromRequest(body.Schedules)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: err.Error(),
 		})
+		return
 	}
 
 	created, err := h.adminRepo.CreateMovie(ctx, movie, genres, casts, schedules)
 	if err != nil {
+		if respondScheduleConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, dtos.Response{
 			Code:    http.StatusInternalServerError,
 			Success: false,
@@ -168,7 +172,7 @@ func (h *AdminHandler) GetMovieByID(ctx *gin.Context) {
 
 // UpdateMovie godoc
 // @Summary Update movie
-// @Description Update movie with optional poster & backdrop upload
+// @Description Update movie with optional poster & backdrop upload. Schedules sent in the form are added to the movie, existing schedules are managed through /admin/schedules.
 // @Tags Admin
 // @Accept multipart/form-data
 // @Produce json
@@ -249,7 +253,20 @@ func (h *AdminHandler) UpdateMovie(ctx *gin.Context) {
 		update["backdrop_path"] = path
 	}
 
-	if err := h.adminRepo.UpdateMovie(ctx, id, update, genres, casts); err != nil {
+	schedules, err := schedulesFromRequest(body.Schedules)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if err := h.adminRepo.UpdateMovie(ctx, id, update, genres, casts, schedules); err != nil {
+		if respondScheduleConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, dtos.Response{
 			Code:    http.StatusInternalServerError,
 			Success: false,
@@ -295,6 +312,115 @@ func (h *AdminHandler) DeleteMovie(ctx *gin.Context) {
 	})
 }
 
+// GetPriceOverrides godoc
+// @Summary Get price overrides
+// @Description Retrieve ticket price overrides per cinema and/or seat class. Each override is an absolute ticket price that replaces the schedule price.
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} dtos.SuccessResponse{data=[]models.PriceOverride} "Price overrides retrieved successfully"
+// @Failure 500 {object} dtos.ErrorResponse "Internal Server Error"
+// @Router /admin/prices [get]
+// @Security BearerAuth
+func (h *AdminHandler) GetPriceOverrides(ctx *gin.Context) {
+	overrides, err := h.adminRepo.GetPriceOverrides(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch price overrides",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    overrides,
+	})
+}
+
+// CreatePriceOverride godoc
+// @Summary Create price override
+// @Description Set an absolute ticket price for a cinema, a seat class, or both. The override replaces the schedule price, it is not added to it. When several overrides match a seat, the most specific one wins: cinema and seat class, then seat class only, then cinema only. Seats without a matching override use the schedule price.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param override body dtos.PriceOverrideRequest true "Price override"
+// @Success 201 {object} dtos.SuccessResponse{data=models.PriceOverride} "Price override created successfully"
+// @Failure 400 {object} dtos.ErrorResponse "Invalid request"
+// @Failure 409 {object} dtos.ErrorResponse "Price override for this cinema and seat class already exists"
+// @Failure 500 {object} dtos.ErrorResponse "Internal server error"
+// @Router /admin/prices [post]
+// @Security BearerAuth
+func (h *AdminHandler) CreatePriceOverride(ctx *gin.Context) {
+	var body dtos.PriceOverrideRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil || (body.CinemaID == nil && body.SeatClass == nil) {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data, cinema_id or seat_class is required",
+		})
+		return
+	}
+
+	override := &models.PriceOverride{
+		CinemaID:  body.CinemaID,
+		SeatClass: body.SeatClass,
+		Price:     body.Price,
+	}
+	if err := h.adminRepo.CreatePriceOverride(ctx, override); err != nil {
+		if errors.Is(err, repos.ErrPriceOverrideExists) {
+			ctx.JSON(http.StatusConflict, dtos.Response{
+				Code:    http.StatusConflict,
+				Success: false,
+				Message: "A price override for this cinema and seat class already exists",
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.Response{
+		Code:    http.StatusCreated,
+		Success: true,
+		Message: "Price override created successfully",
+		Data:    override,
+	})
+}
+
+// DeletePriceOverride godoc
+// @Summary Delete price override
+// @Description Delete a ticket price override by ID
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Price override ID"
+// @Success 200 {object} dtos.SuccessResponse "Price override deleted successfully"
+// @Failure 500 {object} dtos.ErrorResponse "Internal Server Error"
+// @Router /admin/prices/{id} [delete]
+// @Security BearerAuth
+func (h *AdminHandler) DeletePriceOverride(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if err := h.adminRepo.DeletePriceOverride(ctx, id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to delete price override",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Price override deleted successfully",
+	})
+}
+
 func normalizeInputArray(input []string) []string {
 	var out []string
 	for _, item := range input {
@@ -308,3 +434,32 @@ func normalizeInputArray(input []string) []string {
 	}
 	return out
 }
+
+// schedulesFromRequest memecah ScheduleRequest dari form movie menjadi satu schedule per jam tayang
+func schedulesFromRequest(reqs []dtos.ScheduleRequest) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, s := range reqs {
+		date, err := time.Parse("2006-01-02", s.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule date %q, use YYYY-MM-DD", s.Date)
+		}
+		if s.Price < 1 {
+			return nil, fmt.Errorf("schedule price for %s must be greater than 0", s.Date)
+		}
+		hallID := 0
+		if s.HallID != nil {
+			hallID = *s.HallID
+		}
+		for _, tid := range s.TimeIDs {
+			schedules = append(schedules, models.Schedule{
+				CinemaID:   s.CinemaID,
+				LocationID: s.LocationID,
+				HallID:     hallID,
+				TimeID:     tid,
+				Date:       date,
+				Price:      s.Price,
+			})
+		}
+	}
+	return schedules, nil
+}
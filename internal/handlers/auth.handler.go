@@ -101,18 +101,18 @@ func (ah *AuthHandler) Login(ctx *gin.Context) {
 
 // Register godoc
 // @Summary User registration
-// @Description Register a new user account
+// @Description Register a new user account. Send the token from a guest order link as guest_token to move the orders made during that guest checkout into the new account.
 // @Tags Authentication
 // @Accept json
 // @Produce json
-// @Param user body dtos.AuthRequest true "Registration data"
-// @Success 201 {object} dtos.SuccessResponse "Registration successful"
-// @Failure 400 {object} dtos.ErrorResponse "Invalid request body"
+// @Param user body dtos.RegisterRequest true "Registration data"
+// @Success 201 {object} dtos.SuccessResponse{data=dtos.RegisterResponse} "Registration successful"
+// @Failure 400 {object} dtos.ErrorResponse "Invalid request body or guest token"
 // @Failure 409 {object} dtos.ErrorResponse "Email already exists"
 // @Failure 500 {object} dtos.ErrorResponse "Internal server error"
 // @Router /register [post]
 func (ah *AuthHandler) Register(ctx *gin.Context) {
-	body := dtos.AuthRequest{}
+	body := dtos.RegisterRequest{}
 	if err := ctx.ShouldBind(&body); err != nil {
 		log.Println(err.Error())
 		ctx.JSON(http.StatusBadRequest, dtos.Response{
@@ -123,6 +123,21 @@ func (ah *AuthHandler) Register(ctx *gin.Context) {
 		return
 	}
 
+	// token diperiksa sebelum akun dibuat supaya link yang salah tidak menghasilkan akun setengah jadi
+	guestOrderID := 0
+	if body.GuestToken != "" {
+		orderID, err := pkg.VerifyOrderLinkToken(body.GuestToken)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: "Invalid Guest Token",
+			})
+			return
+		}
+		guestOrderID = orderID
+	}
+
 	var hash pkg.HashConfig
 	hash.UseRecommended()
 	hashed, err := hash.GenHash(body.Password)
@@ -152,9 +167,22 @@ func (ah *AuthHandler) Register(ctx *gin.Context) {
 		return
 	}
 
+	claimed := 0
+	if guestOrderID != 0 {
+		// akun sudah terbuat, gagal klaim cukup dicatat dan bisa diulang lewat /guest/claim
+		claimed, err = ah.authRepo.ClaimGuestOrders(ctx.Request.Context(), user.ID, guestOrderID)
+		if err != nil {
+			log.Println("ClaimGuestOrders error:", err)
+		}
+	}
+
 	ctx.JSON(http.StatusCreated, dtos.Response{
 		Code:    http.StatusCreated,
 		Success: true,
 		Message: "Register Successfully",
+		Data: dtos.RegisterResponse{
+			UserID:        user.ID,
+			ClaimedOrders: claimed,
+		},
 	})
 }
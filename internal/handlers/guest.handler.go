@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/Darari17/be-tickitz/internal/utils"
+	"github.com/Darari17/be-tickitz/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type GuestHandler struct {
+	authRepo  *repos.AuthRepo
+	orderRepo *repos.OrderRepo
+}
+
+func NewGuestHandler(ar *repos.AuthRepo, or *repos.OrderRepo) *GuestHandler {
+	return &GuestHandler{authRepo: ar, orderRepo: or}
+}
+
+// CreateGuestToken godoc
+// @Summary Start guest checkout
+// @Description Get a short-lived token for booking without an account. The token can hold seats and create orders under /orders with just a name, email and phone number. A link to the order is sent to that email.
+// @Tags Guest
+// @Produce json
+// @Success 201 {object} dtos.Response{data=dtos.AuthResponse} "Guest token created successfully"
+// @Failure 429 {object} dtos.Response "Too many guest tokens requested from this address"
+// @Failure 500 {object} dtos.Response "Failed to create guest token"
+// @Router /guest/token [post]
+func (gh *GuestHandler) CreateGuestToken(ctx *gin.Context) {
+	user, err := gh.authRepo.CreateGuest(ctx.Request.Context())
+	if err != nil {
+		log.Println("CreateGuest error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to create guest token",
+		})
+		return
+	}
+
+	token, err := pkg.NewJWTClaims(user.ID, string(user.Role)).GenToken()
+	if err != nil {
+		log.Println("GenToken error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to create guest token",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.Response{
+		Code:    http.StatusCreated,
+		Success: true,
+		Message: "Guest token created successfully",
+		Data: dtos.AuthResponse{
+			UserID: user.ID,
+			Token:  token,
+		},
+	})
+}
+
+// GetGuestOrder godoc
+// @Summary Get guest order
+// @Description Retrieve an order through the secure link sent to the guest's email
+// @Tags Guest
+// @Produce json
+// @Param token query string true "Token from the order link"
+// @Success 200 {object} dtos.Response{data=models.OrderDetail} "Order retrieved successfully"
+// @Failure 401 {object} dtos.Response "Invalid or expired order link"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Router /guest/orders [get]
+func (gh *GuestHandler) GetGuestOrder(ctx *gin.Context) {
+	orderID, err := pkg.VerifyOrderLinkToken(ctx.Query("token"))
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Invalid or expired order link",
+		})
+		return
+	}
+
+	order, err := gh.orderRepo.GetTransactionDetail(ctx.Request.Context(), orderID)
+	if err != nil {
+		log.Println("GetTransactionDetail error:", err)
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Order not found",
+		})
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    order,
+	})
+}
+
+// ClaimGuestOrders godoc
+// @Summary Claim guest orders
+// @Description Move the orders made during a guest checkout into the logged in account using the token from the order link
+// @Tags Guest
+// @Accept json
+// @Produce json
+// @Param claim body dtos.ClaimGuestOrderRequest true "Order link token"
+// @Success 200 {object} dtos.Response{data=dtos.RegisterResponse} "Guest orders claimed successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data or order link"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Order is not a guest order"
+// @Failure 500 {object} dtos.Response "Failed to claim guest orders"
+// @Router /guest/claim [post]
+// @Security BearerAuth
+func (gh *GuestHandler) ClaimGuestOrders(ctx *gin.Context) {
+	var body dtos.ClaimGuestOrderRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	orderID, err := pkg.VerifyOrderLinkToken(body.Token)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid or expired order link",
+		})
+		return
+	}
+
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	claimed, err := gh.authRepo.ClaimGuestOrders(ctx.Request.Context(), userID, orderID)
+	if err != nil {
+		if errors.Is(err, repos.ErrGuestOrderNotFound) {
+			ctx.JSON(http.StatusNotFound, dtos.Response{
+				Code:    http.StatusNotFound,
+				Success: false,
+				Message: "Order is not a guest order or has already been claimed",
+			})
+			return
+		}
+		log.Println("ClaimGuestOrders error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to claim guest orders",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Guest orders claimed successfully",
+		Data: dtos.RegisterResponse{
+			UserID:        userID,
+			ClaimedOrders: claimed,
+		},
+	})
+}
+
+// guestOrderLink membuat link order tamu yang dikirim lewat email.
+// GUEST_ORDER_URL adalah halaman frontend yang membaca query token, GUEST_LINK_TTL masa berlakunya.
+func guestOrderLink(orderID int) (string, error) {
+	ttl := 30 * 24 * time.Hour
+	if v, err := time.ParseDuration(os.Getenv("GUEST_LINK_TTL")); err == nil && v > 0 {
+		ttl = v
+	}
+	token, err := pkg.GenOrderLinkToken(orderID, time.Now().Add(ttl))
+	if err != nil {
+		return "", err
+	}
+
+	base := os.Getenv("GUEST_ORDER_URL")
+	if base == "" {
+		base = "http://localhost:8080/guest/orders"
+	}
+	return base + "?token=" + url.QueryEscape(token), nil
+}
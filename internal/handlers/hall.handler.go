@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+)
+
+type HallHandler struct {
+	hallRepo *repos.HallRepo
+}
+
+func NewHallHandler(hr *repos.HallRepo) *HallHandler {
+	return &HallHandler{hallRepo: hr}
+}
+
+// GetHalls godoc
+// @Summary Get halls
+// @Description Retrieve cinema halls, optionally filtered by cinema
+// @Tags Admin
+// @Produce json
+// @Param cinema_id query int false "Cinema ID"
+// @Success 200 {object} dtos.Response{data=[]models.Hall} "Halls retrieved successfully"
+// @Failure 500 {object} dtos.Response "Failed to fetch halls"
+// @Router /admin/halls [get]
+// @Security BearerAuth
+func (hh *HallHandler) GetHalls(ctx *gin.Context) {
+	cinemaID, _ := strconv.Atoi(ctx.Query("cinema_id"))
+	halls, err := hh.hallRepo.GetHalls(ctx.Request.Context(), cinemaID)
+	if err != nil {
+		log.Println("GetHalls error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch halls",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    halls,
+	})
+}
+
+// GetHall godoc
+// @Summary Get hall by ID
+// @Description Retrieve a hall with its seat grid and layout
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Hall ID"
+// @Success 200 {object} dtos.Response{data=models.Hall} "Hall retrieved successfully"
+// @Failure 404 {object} dtos.Response "Hall not found"
+// @Failure 500 {object} dtos.Response "Failed to fetch hall"
+// @Router /admin/halls/{id} [get]
+// @Security BearerAuth
+func (hh *HallHandler) GetHall(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	hall, err := hh.hallRepo.GetHall(ctx.Request.Context(), id)
+	if err != nil {
+		respondHallError(ctx, err, "Failed to fetch hall")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    hall,
+	})
+}
+
+// CreateHall godoc
+// @Summary Create hall
+// @Description Create a hall for a cinema from a row-by-row layout. S = regular, V = vip, C = couple, W = wheelchair, _ = aisle or gap. Rows are lettered from A and seats numbered from the left, skipping gaps.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param hall body dtos.HallRequest true "Hall data"
+// @Success 201 {object} dtos.Response{data=models.Hall} "Hall created successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data or layout"
+// @Failure 404 {object} dtos.Response "Cinema not found"
+// @Failure 409 {object} dtos.Response "Hall name already used"
+// @Failure 500 {object} dtos.Response "Failed to create hall"
+// @Router /admin/halls [post]
+// @Security BearerAuth
+func (hh *HallHandler) CreateHall(ctx *gin.Context) {
+	var body dtos.HallRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	rows, columns, seats, err := models.ParseHallLayout(body.Layout)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid layout: " + err.Error(),
+		})
+		return
+	}
+
+	hall := &models.Hall{
+		CinemaID: body.CinemaID,
+		Name:     body.Name,
+		Rows:     rows,
+		Columns:  columns,
+		Seats:    seats,
+	}
+	if err := hh.hallRepo.CreateHall(ctx.Request.Context(), hall); err != nil {
+		respondHallError(ctx, err, "Failed to create hall")
+		return
+	}
+	hall.Layout = models.HallLayout(hall.Rows, hall.Columns, hall.Seats)
+
+	ctx.JSON(http.StatusCreated, dtos.Response{
+		Code:    http.StatusCreated,
+		Success: true,
+		Message: "Hall created successfully",
+		Data:    hall,
+	})
+}
+
+// UpdateHall godoc
+// @Summary Update hall
+// @Description Rename a hall and/or replace its layout. The layout cannot be replaced once any of its seats has been ordered.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Hall ID"
+// @Param hall body dtos.UpdateHallRequest true "Hall data"
+// @Success 200 {object} dtos.Response{data=models.Hall} "Hall updated successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data or layout"
+// @Failure 404 {object} dtos.Response "Hall not found"
+// @Failure 409 {object} dtos.Response "Hall name already used or seats already ordered"
+// @Failure 500 {object} dtos.Response "Failed to update hall"
+// @Router /admin/halls/{id} [patch]
+// @Security BearerAuth
+func (hh *HallHandler) UpdateHall(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var body dtos.UpdateHallRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	hall, err := hh.hallRepo.GetHall(ctx.Request.Context(), id)
+	if err != nil {
+		respondHallError(ctx, err, "Failed to update hall")
+		return
+	}
+
+	if body.Name != nil {
+		hall.Name = *body.Name
+	}
+	replaceLayout := len(body.Layout) > 0
+	if replaceLayout {
+		hall.Rows, hall.Columns, hall.Seats, err = models.ParseHallLayout(body.Layout)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: "Invalid layout: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	if err := hh.hallRepo.UpdateHall(ctx.Request.Context(), hall, replaceLayout); err != nil {
+		respondHallError(ctx, err, "Failed to update hall")
+		return
+	}
+	hall.Layout = models.HallLayout(hall.Rows, hall.Columns, hall.Seats)
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Hall updated successfully",
+		Data:    hall,
+	})
+}
+
+// DeleteHall godoc
+// @Summary Delete hall
+// @Description Delete a hall and its seats. Halls that already have schedules cannot be deleted.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Hall ID"
+// @Success 200 {object} dtos.Response "Hall deleted successfully"
+// @Failure 404 {object} dtos.Response "Hall not found"
+// @Failure 409 {object} dtos.Response "Hall is still used"
+// @Failure 500 {object} dtos.Response "Failed to delete hall"
+// @Router /admin/halls/{id} [delete]
+// @Security BearerAuth
+func (hh *HallHandler) DeleteHall(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if err := hh.hallRepo.DeleteHall(ctx.Request.Context(), id); err != nil {
+		respondHallError(ctx, err, "Failed to delete hall")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Hall deleted successfully",
+	})
+}
+
+// SetBlockedSeats godoc
+// @Summary Set blocked seats
+// @Description Replace the list of blocked seats in a hall, e.g. broken seats or seats kept for house use. Blocked seats show up as blocked in the seat map and cannot be held or ordered. Send an empty list to unblock every seat.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Hall ID"
+// @Param seats body dtos.BlockedSeatsRequest true "Blocked seat codes"
+// @Success 200 {object} dtos.Response{data=models.Hall} "Blocked seats updated successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data or unknown seats"
+// @Failure 404 {object} dtos.Response "Hall not found"
+// @Failure 500 {object} dtos.Response "Failed to update blocked seats"
+// @Router /admin/halls/{id}/blocked-seats [put]
+// @Security BearerAuth
+func (hh *HallHandler) SetBlockedSeats(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var body dtos.BlockedSeatsRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+	for i, code := range body.SeatCodes {
+		body.SeatCodes[i] = strings.ToUpper(strings.TrimSpace(code))
+	}
+
+	if err := hh.hallRepo.SetBlockedSeats(ctx.Request.Context(), id, body.SeatCodes); err != nil {
+		respondHallError(ctx, err, "Failed to update blocked seats")
+		return
+	}
+
+	hall, err := hh.hallRepo.GetHall(ctx.Request.Context(), id)
+	if err != nil {
+		respondHallError(ctx, err, "Failed to update blocked seats")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Blocked seats updated successfully",
+		Data:    hall,
+	})
+}
+
+func respondHallError(ctx *gin.Context, err error, message string) {
+	var unknownErr *repos.UnknownSeatsError
+	switch {
+	case errors.As(err, &unknownErr):
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: err.Error(),
+		})
+	case errors.Is(err, repos.ErrHallNotFound), errors.Is(err, repos.ErrCinemaNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: err.Error(),
+		})
+	case errors.Is(err, repos.ErrHallExists), errors.Is(err, repos.ErrHallInUse):
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: err.Error(),
+		})
+	default:
+		log.Println("hall error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: message,
+		})
+	}
+}
@@ -1,36 +1,55 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/mailer"
 	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/payments"
 	"github.com/Darari17/be-tickitz/internal/repos"
 	"github.com/Darari17/be-tickitz/internal/utils"
+	"github.com/Darari17/be-tickitz/pkg"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type OrderHandler struct {
-	orderRepo *repos.OrderRepo
+	orderRepo    *repos.OrderRepo
+	seatHoldRepo *repos.SeatHoldRepo
+	seatEvents   *repos.SeatEventRepo
+	paymentRepo  *repos.PaymentRepo
+	providers    *payments.Registry
+	mailer       mailer.Mailer
 }
 
-func NewOrderHandler(or *repos.OrderRepo) *OrderHandler {
-	return &OrderHandler{orderRepo: or}
+func NewOrderHandler(or *repos.OrderRepo, shr *repos.SeatHoldRepo, ser *repos.SeatEventRepo, pr *repos.PaymentRepo, providers *payments.Registry, m mailer.Mailer) *OrderHandler {
+	return &OrderHandler{orderRepo: or, seatHoldRepo: shr, seatEvents: ser, paymentRepo: pr, providers: providers, mailer: m}
 }
 
 // CreateOrder godoc
 // @Summary Create a new order
-// @Description Create a new movie ticket order
+// @Description Create a new movie ticket order. A promo code and loyalty points can be applied as discounts on the ticket price. The response contains the payment provider's instructions or redirect URL.
 // @Tags Orders
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Unique key per checkout attempt. Retries with the same key and payload return the original response, a different payload with the same key is rejected with 422."
 // @Param order body dtos.CreateOrderRequest true "Order creation data"
 // @Success 201 {object} dtos.Response{data=models.Order} "Order created successfully"
-// @Failure 400 {object} dtos.Response "Invalid request payload or seat codes"
+// @Failure 400 {object} dtos.Response "Invalid request payload, seat codes, promo code or redeemed points"
 // @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response{data=[]string} "Showtime already started or cancelled, seats are not held by the user or already booked, or a request with the same Idempotency-Key is still being processed"
+// @Failure 422 {object} dtos.Response "Idempotency-Key reused with a different payload"
 // @Failure 500 {object} dtos.Response "Failed to create order"
+// @Failure 502 {object} dtos.Response "Payment provider unavailable"
 // @Router /orders [post]
 // @Security BearerAuth
 func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
@@ -55,7 +74,19 @@ func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
-	seatIDs, err := oh.orderRepo.GetSeatIDsByCodes(ctx.Request.Context(), req.SeatCodes)
+	if err := oh.orderRepo.CheckScheduleBookable(ctx.Request.Context(), req.ScheduleID); err != nil {
+		if !respondUnbookableSchedule(ctx, err) {
+			log.Println("CheckScheduleBookable error:", err)
+			ctx.JSON(http.StatusInternalServerError, dtos.Response{
+				Code:    http.StatusInternalServerError,
+				Success: false,
+				Message: "Failed to create order",
+			})
+		}
+		return
+	}
+
+	seatIDs, err := oh.orderRepo.GetSeatIDsByCodes(ctx.Request.Context(), req.ScheduleID, req.SeatCodes)
 	if err != nil || len(seatIDs) != len(req.SeatCodes) {
 		ctx.JSON(http.StatusBadRequest, dtos.Response{
 			Code:    http.StatusBadRequest,
@@ -65,17 +96,69 @@ func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
+	method, err := oh.paymentRepo.GetPaymentMethod(ctx.Request.Context(), req.PaymentID)
+	if err != nil {
+		if !errors.Is(err, repos.ErrPaymentMethodNotFound) {
+			log.Println("GetPaymentMethod error:", err)
+		}
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid payment method",
+		})
+		return
+	}
+	provider, err := oh.providers.Get(method.Provider)
+	if err != nil {
+		log.Printf("payment method %d uses unknown provider %q\n", method.ID, method.Provider)
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid payment method",
+		})
+		return
+	}
+
+	if err := oh.seatHoldRepo.VerifyHolds(ctx.Request.Context(), req.ScheduleID, seatIDs, userID); err != nil {
+		if !errors.Is(err, repos.ErrSeatNotHeld) {
+			log.Println("VerifyHolds error:", err)
+		}
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "Seats are not held by you or the hold has expired",
+		})
+		return
+	}
+
 	order := &models.Order{
-		UserID:     userID,
-		ScheduleID: req.ScheduleID,
-		PaymentID:  req.PaymentID,
-		FullName:   req.FullName,
-		Email:      req.Email,
-		Phone:      req.Phone,
+		UserID:         userID,
+		ScheduleID:     req.ScheduleID,
+		PaymentID:      req.PaymentID,
+		FullName:       req.FullName,
+		Email:          req.Email,
+		Phone:          req.Phone,
+		PointsRedeemed: req.RedeemPoints,
+	}
+	if req.PromoCode != "" {
+		order.PromoCode = &req.PromoCode
 	}
 
 	newOrder, err := oh.orderRepo.CreateOrder(ctx.Request.Context(), order, seatIDs)
 	if err != nil {
+		var conflictErr *repos.SeatConflictError
+		if errors.As(err, &conflictErr) {
+			ctx.JSON(http.StatusConflict, dtos.Response{
+				Code:    http.StatusConflict,
+				Success: false,
+				Message: "Some seats are already booked",
+				Data:    conflictErr.SeatCodes,
+			})
+			return
+		}
+		if respondUnbookableSchedule(ctx, err) || respondDiscountError(ctx, err) {
+			return
+		}
 		log.Println("CreateOrder error:", err)
 		ctx.JSON(http.StatusInternalServerError, dtos.Response{
 			Code:    http.StatusInternalServerError,
@@ -85,6 +168,41 @@ func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
+	if err := oh.seatHoldRepo.ReleaseHolds(ctx.Request.Context(), req.ScheduleID, seatIDs, userID); err != nil {
+		log.Println("ReleaseHolds error:", err)
+	}
+	publishSeatEvent(ctx.Request.Context(), oh.seatEvents, models.NewSeatEvent(models.SeatEventSold, req.ScheduleID, seatIDs))
+
+	charge, err := provider.CreateCharge(ctx.Request.Context(), payments.ChargeRequest{
+		OrderID:  newOrder.ID,
+		Amount:   newOrder.Total,
+		FullName: newOrder.FullName,
+		Email:    newOrder.Email,
+		Phone:    newOrder.Phone,
+	})
+	if err == nil {
+		err = oh.paymentRepo.AttachCharge(ctx.Request.Context(), newOrder, provider.Name(), charge)
+	}
+	if err != nil {
+		log.Println("CreateCharge error:", err)
+		// order tanpa tagihan tidak bisa dibayar, batalkan supaya kursinya kembali
+		if _, err := oh.orderRepo.TransitionOrder(ctx.Request.Context(), newOrder.ID, models.OrderCancelled); err != nil {
+			log.Println("TransitionOrder error:", err)
+		} else {
+			publishSeatEvent(ctx.Request.Context(), oh.seatEvents, models.NewSeatEvent(models.SeatEventReleased, req.ScheduleID, seatIDs))
+		}
+		ctx.JSON(http.StatusBadGateway, dtos.Response{
+			Code:    http.StatusBadGateway,
+			Success: false,
+			Message: "Payment provider unavailable",
+		})
+		return
+	}
+
+	// instruksi pembayaran dikirim di background supaya checkout tidak menunggu server SMTP,
+	// tiket baru dikirim setelah order dibayar
+	go sendOrderEmail(oh.orderRepo, oh.mailer, newOrder.ID)
+
 	ctx.JSON(http.StatusCreated, dtos.Response{
 		Code:    http.StatusCreated,
 		Success: true,
@@ -92,36 +210,93 @@ func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
 	})
 }
 
-// GetSchedules godoc
-// @Summary Get movie schedules
-// @Description Retrieve all schedules for a specific movie
+// QuoteOrder godoc
+// @Summary Quote an order
+// @Description Preview the price of an order with an optional promo code and redeemed points without creating it. The quote does not reserve the promo quota or points, checkout validates them again.
 // @Tags Orders
+// @Accept json
 // @Produce json
-// @Param movie_id query int true "Movie ID"
-// @Success 200 {object} dtos.Response{data=[]models.Schedule} "Schedules retrieved successfully"
-// @Failure 400 {object} dtos.Response "Invalid movie_id"
-// @Failure 500 {object} dtos.Response "Failed to fetch schedules"
-// @Router /orders/schedules [get]
+// @Param quote body dtos.QuoteOrderRequest true "Order to quote"
+// @Success 200 {object} dtos.Response{data=dtos.OrderQuoteResponse} "Order quoted successfully"
+// @Failure 400 {object} dtos.Response "Invalid request payload, seat codes, promo code or redeemed points"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response{data=[]string} "Showtime already started or cancelled, or seats already booked"
+// @Failure 500 {object} dtos.Response "Failed to quote order"
+// @Router /orders/quote [post]
 // @Security BearerAuth
-func (oh *OrderHandler) GetSchedules(ctx *gin.Context) {
-	movieIDStr := ctx.Query("movie_id")
-	movieID, err := strconv.Atoi(movieIDStr)
+func (oh *OrderHandler) QuoteOrder(ctx *gin.Context) {
+	var req dtos.QuoteOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request payload",
+		})
+		return
+	}
+
+	userID, _, err := utils.GetUserFromContext(ctx)
 	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	if err := oh.orderRepo.CheckScheduleBookable(ctx.Request.Context(), req.ScheduleID); err != nil {
+		if !respondUnbookableSchedule(ctx, err) {
+			log.Println("CheckScheduleBookable error:", err)
+			ctx.JSON(http.StatusInternalServerError, dtos.Response{
+				Code:    http.StatusInternalServerError,
+				Success: false,
+				Message: "Failed to quote order",
+			})
+		}
+		return
+	}
+
+	seatIDs, err := oh.orderRepo.GetSeatIDsByCodes(ctx.Request.Context(), req.ScheduleID, req.SeatCodes)
+	if err != nil || len(seatIDs) != len(req.SeatCodes) {
 		ctx.JSON(http.StatusBadRequest, dtos.Response{
 			Code:    http.StatusBadRequest,
 			Success: false,
-			Message: "Invalid movie_id",
+			Message: "Invalid seat codes",
 		})
 		return
 	}
 
-	schedules, err := oh.orderRepo.GetSchedules(ctx.Request.Context(), movieID)
+	order := &models.Order{
+		UserID:         userID,
+		ScheduleID:     req.ScheduleID,
+		PointsRedeemed: req.RedeemPoints,
+	}
+	if req.PromoCode != "" {
+		order.PromoCode = &req.PromoCode
+	}
+
+	quote, err := oh.orderRepo.QuoteOrder(ctx.Request.Context(), order, seatIDs)
 	if err != nil {
-		log.Println("GetSchedules error:", err)
+		var conflictErr *repos.SeatConflictError
+		if errors.As(err, &conflictErr) {
+			ctx.JSON(http.StatusConflict, dtos.Response{
+				Code:    http.StatusConflict,
+				Success: false,
+				Message: "Some seats are already booked",
+				Data:    conflictErr.SeatCodes,
+			})
+			return
+		}
+		if respondUnbookableSchedule(ctx, err) || respondDiscountError(ctx, err) {
+			return
+		}
+		log.Println("QuoteOrder error:", err)
 		ctx.JSON(http.StatusInternalServerError, dtos.Response{
 			Code:    http.StatusInternalServerError,
 			Success: false,
-			Message: "Failed to fetch schedules",
+			Message: "Failed to quote order",
 		})
 		return
 	}
@@ -129,82 +304,189 @@ func (oh *OrderHandler) GetSchedules(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, dtos.Response{
 		Code:    http.StatusOK,
 		Success: true,
-		Data:    schedules,
+		Data: dtos.OrderQuoteResponse{
+			ScheduleID:     quote.ScheduleID,
+			Seats:          quote.Seats,
+			Subtotal:       quote.Subtotal,
+			Fee:            quote.Fee,
+			PromoCode:      quote.PromoCode,
+			PromoDiscount:  quote.PromoDiscount,
+			PointsRedeemed: quote.PointsRedeemed,
+			Discount:       quote.Discount,
+			Total:          quote.Total,
+		},
 	})
 }
 
-// GetAvailableSeats godoc
-// @Summary Get available seats
-// @Description Retrieve available seats for a specific schedule
+// respondDiscountError menangani error promo dan poin, mengembalikan true kalau sudah dijawab
+func respondDiscountError(ctx *gin.Context, err error) bool {
+	var promoErr *repos.PromoError
+	switch {
+	case errors.As(err, &promoErr):
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Cannot apply promo code: " + promoErr.Reason,
+		})
+	case errors.Is(err, repos.ErrInsufficientPoints), errors.Is(err, repos.ErrRedeemExceedsPrice):
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Cannot redeem points: " + err.Error(),
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// respondUnbookableSchedule menulis response untuk schedule yang tidak bisa dipesan lagi.
+// mengembalikan false kalau err bukan error schedule.
+func respondUnbookableSchedule(ctx *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, repos.ErrScheduleNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Schedule not found",
+		})
+	case errors.Is(err, repos.ErrScheduleCancelled):
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "This showtime has been cancelled",
+		})
+	case errors.Is(err, repos.ErrScheduleStarted):
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "This showtime has already started",
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// HoldSeats godoc
+// @Summary Hold seats
+// @Description Temporarily hold seats for a schedule before checkout. Holds expire automatically. A user can hold at most SEAT_HOLD_MAX seats (10 by default) per schedule at a time, counting seats already held.
 // @Tags Orders
+// @Accept json
 // @Produce json
-// @Param schedule_id query int true "Schedule ID"
-// @Success 200 {object} dtos.Response{data=[]models.Seat} "Available seats retrieved successfully"
-// @Failure 400 {object} dtos.Response "Invalid schedule_id"
-// @Failure 500 {object} dtos.Response "Failed to fetch seats"
-// @Router /orders/seats [get]
+// @Param hold body dtos.SeatHoldRequest true "Seats to hold"
+// @Success 201 {object} dtos.Response{data=dtos.SeatHoldResponse} "Seats held successfully"
+// @Failure 400 {object} dtos.Response "Invalid request payload or seat codes"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response "Showtime already started or cancelled, seats already held or sold, or too many seats held"
+// @Failure 500 {object} dtos.Response "Failed to hold seats"
+// @Router /orders/holds [post]
 // @Security BearerAuth
-func (oh *OrderHandler) GetAvailableSeats(ctx *gin.Context) {
-	scheduleIDStr := ctx.Query("schedule_id")
-	scheduleID, err := strconv.Atoi(scheduleIDStr)
+func (oh *OrderHandler) HoldSeats(ctx *gin.Context) {
+	req, seats, userID, ok := oh.bindSeatHold(ctx)
+	if !ok {
+		return
+	}
+
+	available, err := oh.orderRepo.GetAvailableSeats(ctx.Request.Context(), req.ScheduleID)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, dtos.Response{
-			Code:    http.StatusBadRequest,
+		log.Println("GetAvailableSeats error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
 			Success: false,
-			Message: "Invalid schedule_id",
+			Message: "Failed to hold seats",
+		})
+		return
+	}
+	if sold := missingSeats(seats, available); len(sold) > 0 {
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "Some seats are already sold",
+			Data:    sold,
 		})
 		return
 	}
 
-	seats, err := oh.orderRepo.GetAvailableSeats(ctx.Request.Context(), scheduleID)
+	expiresAt, err := oh.seatHoldRepo.HoldSeats(ctx.Request.Context(), req.ScheduleID, seatIDsOf(seats), userID)
 	if err != nil {
-		log.Println("GetAvailableSeats error:", err)
+		var heldErr *repos.SeatHeldError
+		if errors.As(err, &heldErr) {
+			ctx.JSON(http.StatusConflict, dtos.Response{
+				Code:    http.StatusConflict,
+				Success: false,
+				Message: "Some seats are held by another user",
+				Data:    seatCodesOf(seats, heldErr.SeatIDs),
+			})
+			return
+		}
+		if errors.Is(err, repos.ErrSeatHoldLimit) {
+			ctx.JSON(http.StatusConflict, dtos.Response{
+				Code:    http.StatusConflict,
+				Success: false,
+				Message: fmt.Sprintf("You can hold at most %d seats for this schedule", oh.seatHoldRepo.MaxSeats()),
+			})
+			return
+		}
+		log.Println("HoldSeats error:", err)
 		ctx.JSON(http.StatusInternalServerError, dtos.Response{
 			Code:    http.StatusInternalServerError,
 			Success: false,
-			Message: "Failed to fetch seats",
+			Message: "Failed to hold seats",
 		})
 		return
 	}
+	publishSeatEvent(ctx.Request.Context(), oh.seatEvents, models.NewSeatEvent(models.SeatEventHeld, req.ScheduleID, seatIDsOf(seats)))
 
-	ctx.JSON(http.StatusOK, dtos.Response{
-		Code:    http.StatusOK,
+	ctx.JSON(http.StatusCreated, dtos.Response{
+		Code:    http.StatusCreated,
 		Success: true,
-		Data:    seats,
+		Message: "Seats held successfully",
+		Data: dtos.SeatHoldResponse{
+			ScheduleID: req.ScheduleID,
+			SeatCodes:  req.SeatCodes,
+			ExpiresAt:  expiresAt,
+		},
 	})
 }
 
-// GetTransactionDetail godoc
-// @Summary Get transaction detail
-// @Description Retrieve detailed information about a specific transaction
+// ExtendSeatHolds godoc
+// @Summary Extend seat holds
+// @Description Extend the expiry of seats currently held by the user
 // @Tags Orders
+// @Accept json
 // @Produce json
-// @Param id path int true "Order ID"
-// @Success 200 {object} dtos.Response{data=models.OrderDetail} "Transaction detail retrieved successfully"
-// @Failure 400 {object} dtos.Response "Invalid order ID"
-// @Failure 404 {object} dtos.Response "Order not found"
-// @Failure 500 {object} dtos.Response "Internal server error"
-// @Router /orders/{id} [get]
+// @Param hold body dtos.SeatHoldRequest true "Held seats to extend"
+// @Success 200 {object} dtos.Response{data=dtos.SeatHoldResponse} "Seat holds extended successfully"
+// @Failure 400 {object} dtos.Response "Invalid request payload or seat codes"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response "Showtime already started or cancelled, or seats are not held by the user"
+// @Failure 500 {object} dtos.Response "Failed to extend seat holds"
+// @Router /orders/holds [patch]
 // @Security BearerAuth
-func (oh *OrderHandler) GetTransactionDetail(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, dtos.Response{
-			Code:    http.StatusBadRequest,
-			Success: false,
-			Message: "Invalid order ID",
-		})
+func (oh *OrderHandler) ExtendSeatHolds(ctx *gin.Context) {
+	req, seats, userID, ok := oh.bindSeatHold(ctx)
+	if !ok {
 		return
 	}
 
-	order, err := oh.orderRepo.GetTransactionDetail(ctx.Request.Context(), id)
+	expiresAt, err := oh.seatHoldRepo.ExtendHolds(ctx.Request.Context(), req.ScheduleID, seatIDsOf(seats), userID)
 	if err != nil {
-		log.Println("GetTransactionDetail error:", err)
-		ctx.JSON(http.StatusNotFound, dtos.Response{
-			Code:    http.StatusNotFound,
+		if errors.Is(err, repos.ErrSeatNotHeld) {
+			ctx.JSON(http.StatusConflict, dtos.Response{
+				Code:    http.StatusConflict,
+				Success: false,
+				Message: "Seats are not held by you or the hold has expired",
+			})
+			return
+		}
+		log.Println("ExtendHolds error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
 			Success: false,
-			Message: "Order not found",
+			Message: "Failed to extend seat holds",
 		})
 		return
 	}
@@ -212,21 +494,65 @@ func (oh *OrderHandler) GetTransactionDetail(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, dtos.Response{
 		Code:    http.StatusOK,
 		Success: true,
-		Data:    order,
+		Message: "Seat holds extended successfully",
+		Data: dtos.SeatHoldResponse{
+			ScheduleID: req.ScheduleID,
+			SeatCodes:  req.SeatCodes,
+			ExpiresAt:  expiresAt,
+		},
 	})
 }
 
-// GetOrderHistory godoc
-// @Summary Get user order history
-// @Description Retrieve order history for the authenticated user
+// ReleaseSeatHolds godoc
+// @Summary Release seat holds
+// @Description Release seats held by the user so other users can pick them
 // @Tags Orders
+// @Accept json
 // @Produce json
-// @Success 200 {object} dtos.Response{data=[]models.OrderDetail} "Order history retrieved successfully"
+// @Param hold body dtos.SeatHoldRequest true "Held seats to release"
+// @Success 200 {object} dtos.Response "Seat holds released successfully"
+// @Failure 400 {object} dtos.Response "Invalid request payload or seat codes"
 // @Failure 401 {object} dtos.Response "Unauthorized"
-// @Failure 500 {object} dtos.Response "Failed to fetch order history"
-// @Router /orders/history [get]
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response "Showtime already started or cancelled"
+// @Failure 500 {object} dtos.Response "Failed to release seat holds"
+// @Router /orders/holds [delete]
 // @Security BearerAuth
-func (oh *OrderHandler) GetOrderHistory(ctx *gin.Context) {
+func (oh *OrderHandler) ReleaseSeatHolds(ctx *gin.Context) {
+	req, seats, userID, ok := oh.bindSeatHold(ctx)
+	if !ok {
+		return
+	}
+
+	if err := oh.seatHoldRepo.ReleaseHolds(ctx.Request.Context(), req.ScheduleID, seatIDsOf(seats), userID); err != nil {
+		log.Println("ReleaseHolds error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to release seat holds",
+		})
+		return
+	}
+	publishSeatEvent(ctx.Request.Context(), oh.seatEvents, models.NewSeatEvent(models.SeatEventReleased, req.ScheduleID, seatIDsOf(seats)))
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Seat holds released successfully",
+	})
+}
+
+func (oh *OrderHandler) bindSeatHold(ctx *gin.Context) (dtos.SeatHoldRequest, []models.Seat, uuid.UUID, bool) {
+	var req dtos.SeatHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request payload",
+		})
+		return req, nil, uuid.Nil, false
+	}
+
 	userID, _, err := utils.GetUserFromContext(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusUnauthorized, dtos.Response{
@@ -234,16 +560,93 @@ func (oh *OrderHandler) GetOrderHistory(ctx *gin.Context) {
 			Success: false,
 			Message: "Unauthorized",
 		})
+		return req, nil, uuid.Nil, false
+	}
+
+	if err := oh.orderRepo.CheckScheduleBookable(ctx.Request.Context(), req.ScheduleID); err != nil {
+		if !respondUnbookableSchedule(ctx, err) {
+			log.Println("CheckScheduleBookable error:", err)
+			ctx.JSON(http.StatusInternalServerError, dtos.Response{
+				Code:    http.StatusInternalServerError,
+				Success: false,
+				Message: "Failed to process seat holds",
+			})
+		}
+		return req, nil, uuid.Nil, false
+	}
+
+	seats, err := oh.orderRepo.GetSeatsByCodes(ctx.Request.Context(), req.ScheduleID, req.SeatCodes)
+	if err != nil || len(seats) != len(req.SeatCodes) {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid seat codes",
+		})
+		return req, nil, uuid.Nil, false
+	}
+
+	return req, seats, userID, true
+}
+
+func seatIDsOf(seats []models.Seat) []int {
+	ids := make([]int, 0, len(seats))
+	for _, s := range seats {
+		ids = append(ids, s.ID)
+	}
+	return ids
+}
+
+func seatCodesOf(seats []models.Seat, ids []int) []string {
+	var codes []string
+	for _, s := range seats {
+		if slices.Contains(ids, s.ID) {
+			codes = append(codes, s.SeatCode)
+		}
+	}
+	return codes
+}
+
+// missingSeats mengembalikan kode kursi yang tidak ada di daftar kursi tersedia
+func missingSeats(seats, available []models.Seat) []string {
+	var codes []string
+	for _, s := range seats {
+		if !slices.ContainsFunc(available, func(a models.Seat) bool { return a.ID == s.ID }) {
+			codes = append(codes, s.SeatCode)
+		}
+	}
+	return codes
+}
+
+// GetSchedules godoc
+// @Summary Get movie schedules
+// @Description Retrieve all schedules for a specific movie
+// @Tags Orders
+// @Produce json
+// @Param movie_id query int true "Movie ID"
+// @Success 200 {object} dtos.Response{data=[]models.Schedule} "Schedules retrieved successfully"
+// @Failure 400 {object} dtos.Response "Invalid movie_id"
+// @Failure 500 {object} dtos.Response "Failed to fetch schedules"
+// @Router /orders/schedules [get]
+// @Security BearerAuth
+func (oh *OrderHandler) GetSchedules(ctx *gin.Context) {
+	movieIDStr := ctx.Query("movie_id")
+	movieID, err := strconv.Atoi(movieIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid movie_id",
+		})
 		return
 	}
 
-	orders, err := oh.orderRepo.GetOrderHistory(ctx.Request.Context(), userID)
+	schedules, err := oh.orderRepo.GetSchedules(ctx.Request.Context(), movieID)
 	if err != nil {
-		log.Println("GetOrderHistory error:", err)
+		log.Println("GetSchedules error:", err)
 		ctx.JSON(http.StatusInternalServerError, dtos.Response{
 			Code:    http.StatusInternalServerError,
 			Success: false,
-			Message: "Failed to fetch order history",
+			Message: "Failed to fetch schedules",
 		})
 		return
 	}
@@ -251,6 +654,766 @@ func (oh *OrderHandler) GetOrderHistory(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, dtos.Response{
 		Code:    http.StatusOK,
 		Success: true,
-		Data:    orders,
+		Data:    schedules,
 	})
 }
+
+// GetAvailableSeats godoc
+// @Summary Get available seats
+// @Description Retrieve available seats for a specific schedule. Seats held by other users are excluded.
+// @Tags Orders
+// @Produce json
+// @Param schedule_id query int true "Schedule ID"
+// @Success 200 {object} dtos.Response{data=[]models.Seat} "Available seats retrieved successfully"
+// @Failure 400 {object} dtos.Response "Invalid schedule_id"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response "Showtime already started or cancelled"
+// @Failure 500 {object} dtos.Response "Failed to fetch seats"
+// @Router /orders/seats [get]
+// @Security BearerAuth
+func (oh *OrderHandler) GetAvailableSeats(ctx *gin.Context) {
+	scheduleIDStr := ctx.Query("schedule_id")
+	scheduleID, err := strconv.Atoi(scheduleIDStr)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid schedule_id",
+		})
+		return
+	}
+
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	if err := oh.orderRepo.CheckScheduleBookable(ctx.Request.Context(), scheduleID); err != nil {
+		if !respondUnbookableSchedule(ctx, err) {
+			log.Println("CheckScheduleBookable error:", err)
+			ctx.JSON(http.StatusInternalServerError, dtos.Response{
+				Code:    http.StatusInternalServerError,
+				Success: false,
+				Message: "Failed to fetch seats",
+			})
+		}
+		return
+	}
+
+	seats, err := oh.orderRepo.GetAvailableSeats(ctx.Request.Context(), scheduleID)
+	if err != nil {
+		log.Println("GetAvailableSeats error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch seats",
+		})
+		return
+	}
+
+	held, err := oh.seatHoldRepo.GetHeldSeats(ctx.Request.Context(), scheduleID)
+	if err != nil {
+		log.Println("GetHeldSeats error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch seats",
+		})
+		return
+	}
+
+	// kursi yang di-hold user lain tidak ditampilkan
+	seats = slices.DeleteFunc(seats, func(s models.Seat) bool {
+		owner, isHeld := held[s.ID]
+		return isHeld && owner != userID
+	})
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    seats,
+	})
+}
+
+// GetSeatMap godoc
+// @Summary Get seat map
+// @Description Retrieve the full seat grid of the schedule's hall. Every row has one cell per column, null cells are aisles or gaps. Each seat carries its type (regular, vip, couple, wheelchair), price class, price and status (available, held, sold, blocked).
+// @Tags Orders
+// @Produce json
+// @Param schedule_id query int true "Schedule ID"
+// @Success 200 {object} dtos.Response{data=models.SeatMap} "Seat map retrieved successfully"
+// @Failure 400 {object} dtos.Response "Invalid schedule_id"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response "Showtime already started or cancelled"
+// @Failure 500 {object} dtos.Response "Failed to fetch seat map"
+// @Router /orders/seats/map [get]
+// @Security BearerAuth
+func (oh *OrderHandler) GetSeatMap(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Query("schedule_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid schedule_id",
+		})
+		return
+	}
+
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	if err := oh.orderRepo.CheckScheduleBookable(ctx.Request.Context(), scheduleID); err != nil {
+		if !respondUnbookableSchedule(ctx, err) {
+			log.Println("CheckScheduleBookable error:", err)
+			ctx.JSON(http.StatusInternalServerError, dtos.Response{
+				Code:    http.StatusInternalServerError,
+				Success: false,
+				Message: "Failed to fetch seat map",
+			})
+		}
+		return
+	}
+
+	seatMap, err := oh.orderRepo.GetSeatMap(ctx.Request.Context(), scheduleID)
+	if errors.Is(err, repos.ErrScheduleNotFound) {
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Schedule not found",
+		})
+		return
+	}
+	if err != nil {
+		log.Println("GetSeatMap error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch seat map",
+		})
+		return
+	}
+
+	held, err := oh.seatHoldRepo.GetHeldSeats(ctx.Request.Context(), scheduleID)
+	if err != nil {
+		log.Println("GetHeldSeats error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch seat map",
+		})
+		return
+	}
+
+	seatMap.ApplyHolds(held, userID)
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    seatMap,
+	})
+}
+
+// GetTransactionDetail godoc
+// @Summary Get transaction detail
+// @Description Retrieve detailed information about a specific transaction. Only the owner of the order or an admin can see it.
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} dtos.Response{data=models.OrderDetail} "Transaction detail retrieved successfully"
+// @Failure 400 {object} dtos.Response "Invalid order ID"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Router /orders/{id} [get]
+// @Security BearerAuth
+func (oh *OrderHandler) GetTransactionDetail(ctx *gin.Context) {
+	// hanya pemilik order atau admin, token tamu tidak boleh membaca order orang lain
+	owned, ok := oh.loadOwnedOrder(ctx)
+	if !ok {
+		return
+	}
+
+	order, err := oh.orderRepo.GetTransactionDetail(ctx.Request.Context(), owned.ID)
+	if err != nil {
+		log.Println("GetTransactionDetail error:", err)
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Order not found",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    order,
+	})
+}
+
+// GetOrderHistory godoc
+// @Summary Get user order history
+// @Description Retrieve order history for the authenticated user
+// @Tags Orders
+// @Produce json
+// @Success 200 {object} dtos.Response{data=[]models.OrderDetail} "Order history retrieved successfully"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 500 {object} dtos.Response "Failed to fetch order history"
+// @Router /orders/history [get]
+// @Security BearerAuth
+func (oh *OrderHandler) GetOrderHistory(ctx *gin.Context) {
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	orders, err := oh.orderRepo.GetOrderHistory(ctx.Request.Context(), userID)
+	if err != nil {
+		log.Println("GetOrderHistory error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch order history",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    orders,
+	})
+}
+
+// ConfirmPayment godoc
+// @Summary Confirm order payment
+// @Description Mark a pending order as paid (admin only)
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} dtos.Response{data=models.Order} "Payment confirmed successfully"
+// @Failure 400 {object} dtos.Response "Invalid order ID"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Failure 409 {object} dtos.Response "Order status does not allow this action"
+// @Failure 500 {object} dtos.Response "Failed to confirm payment"
+// @Router /orders/{id}/pay [post]
+// @Security BearerAuth
+func (oh *OrderHandler) ConfirmPayment(ctx *gin.Context) {
+	order, ok := oh.loadOwnedOrder(ctx)
+	if !ok {
+		return
+	}
+
+	updated, err := oh.orderRepo.TransitionOrder(ctx.Request.Context(), order.ID, models.OrderPaid)
+	if err != nil {
+		respondTransitionError(ctx, err, "Failed to confirm payment")
+		return
+	}
+	go sendOrderEmail(oh.orderRepo, oh.mailer, updated.ID)
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Payment confirmed successfully",
+		Data:    updated,
+	})
+}
+
+// CancelOrder godoc
+// @Summary Cancel order
+// @Description Cancel an order owned by the authenticated user and release its seats. Unpaid orders are simply cancelled. Paid orders are refunded following the refund policy (REFUND_POLICY, by default 100% until 24h before the show and 50% until 2h before) and cannot be cancelled after the last refund window. The refund is recorded before it is sent to the payment provider, a refund the provider rejects stays pending in /admin/refunds.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param cancel body dtos.CancelOrderRequest false "Cancellation reason"
+// @Success 200 {object} dtos.Response{data=models.Order} "Order cancelled successfully"
+// @Failure 400 {object} dtos.Response "Invalid order ID or request payload"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Failure 409 {object} dtos.Response "Order status or refund policy does not allow cancellation"
+// @Failure 500 {object} dtos.Response "Failed to cancel order"
+// @Router /orders/{id}/cancel [post]
+// @Security BearerAuth
+func (oh *OrderHandler) CancelOrder(ctx *gin.Context) {
+	order, ok := oh.loadOwnedOrder(ctx)
+	if !ok {
+		return
+	}
+
+	// body boleh kosong, alasan pembatalan tidak wajib
+	var body dtos.CancelOrderRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&body); err != nil {
+			ctx.JSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: "Invalid request payload",
+			})
+			return
+		}
+	}
+
+	updated, refund, err := oh.orderRepo.CancelOrder(ctx.Request.Context(), order.ID, body.Reason, nil)
+	if err != nil {
+		respondCancelError(ctx, err, "Failed to cancel order")
+		return
+	}
+	publishOrderSeats(ctx.Request.Context(), oh.orderRepo, oh.seatEvents, updated)
+
+	message := "Order cancelled successfully"
+	if !oh.sendRefund(ctx.Request.Context(), refund) {
+		message += ", the refund is still being processed"
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: message,
+		Data:    updated,
+	})
+}
+
+// RefundOrder godoc
+// @Summary Refund order
+// @Description Cancel any order as admin and release its seats. Paid orders are refunded following the refund policy unless an explicit amount is given, e.g. a full refund when the show itself is cancelled. An amount of 0 cancels without refund. A refund the payment provider rejects stays pending in /admin/refunds.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param refund body dtos.RefundOrderRequest true "Refund data"
+// @Success 200 {object} dtos.Response{data=models.Order} "Order refunded successfully"
+// @Failure 400 {object} dtos.Response "Invalid order ID, request payload or amount"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Failure 409 {object} dtos.Response "Order status or refund policy does not allow a refund"
+// @Failure 500 {object} dtos.Response "Failed to refund order"
+// @Router /admin/orders/{id}/refund [post]
+// @Security BearerAuth
+func (oh *OrderHandler) RefundOrder(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid order ID",
+		})
+		return
+	}
+
+	var body dtos.RefundOrderRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request payload",
+		})
+		return
+	}
+
+	updated, refund, err := oh.orderRepo.CancelOrder(ctx.Request.Context(), id, body.Reason, body.Amount)
+	if err != nil {
+		respondCancelError(ctx, err, "Failed to refund order")
+		return
+	}
+	publishOrderSeats(ctx.Request.Context(), oh.orderRepo, oh.seatEvents, updated)
+
+	message := "Order refunded successfully"
+	if !oh.sendRefund(ctx.Request.Context(), refund) {
+		message += ", the refund is still being processed"
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: message,
+		Data:    updated,
+	})
+}
+
+// sendRefund meneruskan refund yang sudah dicatat ke payment provider.
+// mengembalikan false kalau provider gagal, refund tetap pending untuk diulang admin.
+func (oh *OrderHandler) sendRefund(ctx context.Context, refund *models.Refund) bool {
+	if refund == nil {
+		return true
+	}
+	processed, err := processRefund(ctx, oh.paymentRepo, oh.providers, refund)
+	if err != nil {
+		log.Println("processRefund error:", err)
+		return false
+	}
+	return processed.Status == models.RefundSucceeded
+}
+
+func respondCancelError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repos.ErrRefundClosed):
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "The refund window for this order has closed",
+		})
+	case errors.Is(err, repos.ErrRefundAmount):
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Refund amount cannot be more than the order total",
+		})
+	default:
+		respondTransitionError(ctx, err, message)
+	}
+}
+
+// loadOwnedOrder mengambil order dari path param, hanya pemilik order atau admin yang boleh
+func (oh *OrderHandler) loadOwnedOrder(ctx *gin.Context) (*models.Order, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid order ID",
+		})
+		return nil, false
+	}
+
+	userID, role, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return nil, false
+	}
+
+	order, err := oh.orderRepo.GetOrder(ctx.Request.Context(), id)
+	if err != nil || (order.UserID != userID && role != string(models.RoleAdmin)) {
+		if err != nil && !errors.Is(err, repos.ErrOrderNotFound) {
+			log.Println("GetOrder error:", err)
+		}
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Order not found",
+		})
+		return nil, false
+	}
+	return order, true
+}
+
+// publishOrderSeats mengabarkan kursi order yang kembali tersedia setelah order batal / expired
+func publishOrderSeats(ctx context.Context, or *repos.OrderRepo, ser *repos.SeatEventRepo, order *models.Order) {
+	if order.Status.ConsumesSeats() {
+		return
+	}
+	seatIDs, err := or.GetOrderSeatIDs(ctx, order.ID)
+	if err != nil {
+		log.Println("GetOrderSeatIDs error:", err)
+		return
+	}
+	publishSeatEvent(ctx, ser, models.NewSeatEvent(models.SeatEventReleased, order.ScheduleID, seatIDs))
+}
+
+// publishSeatEvent cukup dicatat kalau gagal, client tetap bisa memuat ulang seat map
+func publishSeatEvent(ctx context.Context, ser *repos.SeatEventRepo, event models.SeatEvent) {
+	if err := ser.Publish(ctx, event); err != nil {
+		log.Println("PublishSeatEvent error:", err)
+	}
+}
+
+func respondTransitionError(ctx *gin.Context, err error, message string) {
+	var transitionErr *repos.InvalidTransitionError
+	switch {
+	case errors.As(err, &transitionErr):
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: fmt.Sprintf("Order is %s and cannot be %s", transitionErr.From, transitionErr.To),
+		})
+	case errors.Is(err, repos.ErrOrderNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Order not found",
+		})
+	default:
+		log.Println("TransitionOrder error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: message,
+		})
+	}
+}
+
+// GetTicketQR godoc
+// @Summary Get ticket QR code
+// @Description Render the signed ticket token of a paid order as a PNG QR code
+// @Tags Orders
+// @Produce png
+// @Param id path int true "Order ID"
+// @Success 200 {file} file "QR code image"
+// @Failure 400 {object} dtos.Response "Invalid order ID"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Failure 409 {object} dtos.Response "Order is not paid, its seats were transferred or ticket token is invalid"
+// @Failure 500 {object} dtos.Response "Failed to generate QR code"
+// @Router /orders/{id}/qr [get]
+// @Security BearerAuth
+func (oh *OrderHandler) GetTicketQR(ctx *gin.Context) {
+	order, ok := oh.loadOwnedOrder(ctx)
+	if !ok {
+		return
+	}
+
+	if respondTransferredTicket(ctx, order.Status) {
+		return
+	}
+	if order.Status != models.OrderPaid && order.Status != models.OrderUsed {
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "Order is not paid",
+		})
+		return
+	}
+
+	png, err := ticketQRCode(order.ID, order.QRCode)
+	if err != nil {
+		respondTicketQRError(ctx, err, "Failed to generate QR code")
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// ReissueTicketQR godoc
+// @Summary Reissue ticket QR code
+// @Description Issue a new signed ticket token for a paid order and return it as a PNG QR code. The previous QR code stops working. Only the order's owner or an admin can reissue a ticket.
+// @Tags Orders
+// @Produce png
+// @Param id path int true "Order ID"
+// @Success 200 {file} file "QR code image"
+// @Failure 400 {object} dtos.Response "Invalid order ID"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Failure 409 {object} dtos.Response "Order is not paid or its seats were transferred"
+// @Failure 500 {object} dtos.Response "Failed to reissue ticket"
+// @Router /orders/{id}/qr/reissue [post]
+// @Security BearerAuth
+func (oh *OrderHandler) ReissueTicketQR(ctx *gin.Context) {
+	order, ok := oh.loadOwnedOrder(ctx)
+	if !ok {
+		return
+	}
+
+	if respondTransferredTicket(ctx, order.Status) {
+		return
+	}
+	if order.Status != models.OrderPaid {
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "Order is not paid",
+		})
+		return
+	}
+
+	token, err := oh.orderRepo.ReissueTicketToken(ctx.Request.Context(), order.ID)
+	if err != nil {
+		log.Println("ReissueTicketToken error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to reissue ticket",
+		})
+		return
+	}
+
+	png, err := ticketQRCode(order.ID, token)
+	if err != nil {
+		respondTicketQRError(ctx, err, "Failed to reissue ticket")
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// GetTicketPDF godoc
+// @Summary Download e-ticket
+// @Description Download a printable PDF ticket of a paid order. Only the order's owner can download it.
+// @Tags Orders
+// @Produce application/pdf
+// @Param id path int true "Order ID"
+// @Success 200 {file} file "PDF ticket"
+// @Failure 400 {object} dtos.Response "Invalid order ID"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Failure 409 {object} dtos.Response "Order is not paid, its seats were transferred or ticket token is invalid"
+// @Failure 500 {object} dtos.Response "Failed to generate ticket"
+// @Router /orders/{id}/ticket.pdf [get]
+// @Security BearerAuth
+func (oh *OrderHandler) GetTicketPDF(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid order ID",
+		})
+		return
+	}
+
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	detail, err := oh.orderRepo.GetTransactionDetail(ctx.Request.Context(), id)
+	if err != nil || detail.UserID != userID {
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Order not found",
+		})
+		return
+	}
+
+	if respondTransferredTicket(ctx, detail.Status) {
+		return
+	}
+	if detail.Status != models.OrderPaid && detail.Status != models.OrderUsed {
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "Order is not paid",
+		})
+		return
+	}
+
+	png, err := ticketQRCode(detail.ID, detail.QRCode)
+	if err != nil {
+		respondTicketQRError(ctx, err, "Failed to generate ticket")
+		return
+	}
+
+	pdf, err := utils.RenderTicketPDF(detail, png)
+	if err != nil {
+		log.Println("RenderTicketPDF error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to generate ticket",
+		})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tickitz-ticket-%d.pdf"`, detail.ID))
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// ticketQRCode membuat PNG QR code dari token tiket order. token yang tidak valid atau milik order lain
+// menghasilkan pkg.ErrInvalidTicket, token baru hanya dibuat lewat ReissueTicketQR
+func ticketQRCode(orderID int, token string) ([]byte, error) {
+	id, err := pkg.VerifyTicketToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if id != orderID {
+		return nil, pkg.ErrInvalidTicket
+	}
+	return utils.GenerateQRCode(token, 512)
+}
+
+// respondTransferredTicket menolak tiket order yang seluruh kursinya sudah ditransfer
+func respondTransferredTicket(ctx *gin.Context, status models.OrderStatus) bool {
+	if status != models.OrderTransferred {
+		return false
+	}
+	ctx.JSON(http.StatusConflict, dtos.Response{
+		Code:    http.StatusConflict,
+		Success: false,
+		Message: "Order has no seats left, they were transferred",
+	})
+	return true
+}
+
+func respondTicketQRError(ctx *gin.Context, err error, message string) {
+	if errors.Is(err, pkg.ErrInvalidTicket) {
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "Ticket token is invalid, reissue the ticket",
+		})
+		return
+	}
+	log.Println("GenerateQRCode error:", err)
+	ctx.JSON(http.StatusInternalServerError, dtos.Response{
+		Code:    http.StatusInternalServerError,
+		Success: false,
+		Message: message,
+	})
+}
+
+// sendOrderEmail mengirim email order ke alamat email order. order pending mendapat instruksi pembayaran,
+// order yang sudah dibayar mendapat tiket beserta QR code. order tamu mendapat link untuk membuka order-nya lagi tanpa login.
+func sendOrderEmail(or *repos.OrderRepo, m mailer.Mailer, orderID int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	detail, err := or.GetTransactionDetail(ctx, orderID)
+	if err != nil {
+		log.Println("sendOrderEmail error:", err)
+		return
+	}
+
+	var png []byte
+	if detail.Status == models.OrderPaid {
+		if png, err = ticketQRCode(detail.ID, detail.QRCode); err != nil {
+			log.Println("sendOrderEmail error:", err)
+			return
+		}
+	}
+
+	var link string
+	guest, err := or.IsGuestOrder(ctx, detail.ID)
+	if err != nil {
+		log.Println("sendOrderEmail error:", err)
+		return
+	}
+	if guest {
+		if link, err = guestOrderLink(detail.ID); err != nil {
+			log.Println("sendOrderEmail error:", err)
+			return
+		}
+	}
+
+	msg, err := utils.RenderOrderEmail(detail, png, link)
+	if err != nil {
+		log.Println("sendOrderEmail error:", err)
+		return
+	}
+	if err := m.Send(ctx, msg); err != nil {
+		log.Println("sendOrderEmail error:", err)
+	}
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/mailer"
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/payments"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentHandler struct {
+	paymentRepo *repos.PaymentRepo
+	orderRepo   *repos.OrderRepo
+	seatEvents  *repos.SeatEventRepo
+	providers   *payments.Registry
+	mailer      mailer.Mailer
+}
+
+func NewPaymentHandler(pr *repos.PaymentRepo, or *repos.OrderRepo, ser *repos.SeatEventRepo, providers *payments.Registry, m mailer.Mailer) *PaymentHandler {
+	return &PaymentHandler{paymentRepo: pr, orderRepo: or, seatEvents: ser, providers: providers, mailer: m}
+}
+
+// Webhook godoc
+// @Summary Payment provider webhook
+// @Description Receive a signed payment notification from a provider and update the order. Repeated events are acknowledged without being processed again. A payment for an order that already expired or was cancelled is refunded, and if the refund fails it stays pending under /admin/refunds.
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param provider path string true "Payment provider name" example(local)
+// @Param X-Signature header string true "Hex HMAC-SHA256 of the request body"
+// @Param event body payments.WebhookEvent true "Webhook event"
+// @Success 200 {object} dtos.Response{data=models.Order} "Webhook processed"
+// @Failure 400 {object} dtos.Response "Invalid payload"
+// @Failure 401 {object} dtos.Response "Invalid signature"
+// @Failure 404 {object} dtos.Response "Unknown provider or order"
+// @Failure 500 {object} dtos.Response "Failed to process webhook"
+// @Router /payments/webhook/{provider} [post]
+func (ph *PaymentHandler) Webhook(ctx *gin.Context) {
+	provider, err := ph.providers.Get(ctx.Param("provider"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Unknown payment provider",
+		})
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(ctx.Request.Body, 1<<20))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid payload",
+		})
+		return
+	}
+
+	event, err := provider.ParseWebhook(payload, ctx.Request.Header)
+	if err != nil {
+		if errors.Is(err, payments.ErrInvalidSignature) {
+			ctx.JSON(http.StatusUnauthorized, dtos.Response{
+				Code:    http.StatusUnauthorized,
+				Success: false,
+				Message: "Invalid signature",
+			})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid payload",
+		})
+		return
+	}
+
+	order, refund, duplicate, err := ph.paymentRepo.ProcessWebhook(ctx.Request.Context(), provider.Name(), event, payload)
+	if err != nil {
+		switch {
+		case errors.Is(err, repos.ErrOrderNotFound):
+			ctx.JSON(http.StatusNotFound, dtos.Response{
+				Code:    http.StatusNotFound,
+				Success: false,
+				Message: "Order not found",
+			})
+		case errors.Is(err, repos.ErrAmountMismatch):
+			ctx.JSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: "Paid amount does not match order total",
+			})
+		default:
+			log.Println("ProcessWebhook error:", err)
+			ctx.JSON(http.StatusInternalServerError, dtos.Response{
+				Code:    http.StatusInternalServerError,
+				Success: false,
+				Message: "Failed to process webhook",
+			})
+		}
+		return
+	}
+
+	message := "Webhook processed"
+	if duplicate {
+		message = "Webhook already processed"
+	} else {
+		// pembayaran gagal / expired mengembalikan kursi ke seat picker
+		publishOrderSeats(ctx.Request.Context(), ph.orderRepo, ph.seatEvents, order)
+		if order.Status == models.OrderPaid {
+			go sendOrderEmail(ph.orderRepo, ph.mailer, order.ID)
+		}
+		// refund yang gagal tetap pending dan muncul di /admin/refunds
+		if refund != nil {
+			if _, err := processRefund(ctx.Request.Context(), ph.paymentRepo, ph.providers, refund); err != nil {
+				log.Println("processRefund error:", err)
+			}
+		}
+	}
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: message,
+		Data:    order,
+	})
+}
+
+// GetRefunds godoc
+// @Summary Get refunds
+// @Description Retrieve refunds sent through payment providers, newest first. Pending refunds still have to be paid back, for example a payment that arrived after its order expired or a provider call that failed.
+// @Tags Admin
+// @Produce json
+// @Param status query string false "pending or succeeded, empty for both" Enums(pending, succeeded)
+// @Success 200 {object} dtos.Response{data=[]models.Refund} "Refunds retrieved successfully"
+// @Failure 400 {object} dtos.Response "Invalid status"
+// @Failure 500 {object} dtos.Response "Failed to fetch refunds"
+// @Router /admin/refunds [get]
+// @Security BearerAuth
+func (ph *PaymentHandler) GetRefunds(ctx *gin.Context) {
+	status := ctx.Query("status")
+	if status != "" && status != string(models.RefundPending) && status != string(models.RefundSucceeded) {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid status, use pending or succeeded",
+		})
+		return
+	}
+
+	refunds, err := ph.paymentRepo.GetRefunds(ctx.Request.Context(), status)
+	if err != nil {
+		log.Println("GetRefunds error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch refunds",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    refunds,
+	})
+}
+
+// RetryRefund godoc
+// @Summary Retry refund
+// @Description Send a pending refund to the payment provider again
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Refund ID"
+// @Success 200 {object} dtos.Response{data=models.Refund} "Refund sent successfully"
+// @Failure 404 {object} dtos.Response "Pending refund not found"
+// @Failure 500 {object} dtos.Response "Failed to retry refund"
+// @Failure 502 {object} dtos.Response{data=models.Refund} "Payment provider refund failed"
+// @Router /admin/refunds/{id}/retry [post]
+// @Security BearerAuth
+func (ph *PaymentHandler) RetryRefund(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	refund, err := ph.paymentRepo.GetRefund(ctx.Request.Context(), id)
+	if err == nil && refund.Status != models.RefundPending {
+		err = repos.ErrRefundNotFound
+	}
+	if err == nil {
+		refund, err = processRefund(ctx.Request.Context(), ph.paymentRepo, ph.providers, refund)
+	}
+
+	switch {
+	case errors.Is(err, repos.ErrRefundNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Pending refund not found",
+		})
+	case err != nil:
+		log.Println("RetryRefund error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to retry refund",
+		})
+	case refund.Status != models.RefundSucceeded:
+		ctx.JSON(http.StatusBadGateway, dtos.Response{
+			Code:    http.StatusBadGateway,
+			Success: false,
+			Message: "Payment provider refund failed",
+			Data:    refund,
+		})
+	default:
+		ctx.JSON(http.StatusOK, dtos.Response{
+			Code:    http.StatusOK,
+			Success: true,
+			Message: "Refund sent successfully",
+			Data:    refund,
+		})
+	}
+}
+
+// processRefund mengirim refund pending ke provider lalu mencatat hasilnya.
+// error hanya dikembalikan kalau hasilnya gagal dicatat, kegagalan provider tersimpan di LastError.
+// order yang dikonfirmasi manual tidak punya tagihan di provider sehingga langsung dianggap selesai.
+func processRefund(ctx context.Context, pr *repos.PaymentRepo, providers *payments.Registry, refund *models.Refund) (*models.Refund, error) {
+	var refundErr error
+	if refund.Provider != nil && refund.PaymentReference != nil {
+		provider, err := providers.Get(*refund.Provider)
+		if err == nil {
+			err = provider.Refund(ctx, *refund.PaymentReference, refund.Key(), refund.Amount)
+		}
+		refundErr = err
+	}
+	if refundErr != nil {
+		log.Printf("refund %d for order %d failed: %v\n", refund.ID, refund.OrderID, refundErr)
+	}
+	return pr.RecordRefundAttempt(ctx, refund.ID, refundErr)
+}
@@ -67,6 +67,44 @@ func (ph *ProfileHandler) GetProfile(ctx *gin.Context) {
 	})
 }
 
+// GetPoints godoc
+// @Summary Get loyalty points
+// @Description Retrieve the point balance and the dated history of point changes for the authenticated user
+// @Tags Profile
+// @Produce json
+// @Success 200 {object} dtos.Response{data=models.PointsHistory} "Points retrieved successfully"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Profile not found"
+// @Router /profile/points [get]
+// @Security BearerAuth
+func (ph *ProfileHandler) GetPoints(ctx *gin.Context) {
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized: " + err.Error(),
+		})
+		return
+	}
+
+	points, err := ph.profileRepo.GetPointHistory(ctx.Request.Context(), userID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Profile not found",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    points,
+	})
+}
+
 // UpdateProfile godoc
 // @Summary Update user profile
 // @Description Update profile information for the authenticated user
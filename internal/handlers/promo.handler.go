@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+)
+
+type PromoHandler struct {
+	promoRepo *repos.PromoRepo
+}
+
+func NewPromoHandler(pr *repos.PromoRepo) *PromoHandler {
+	return &PromoHandler{promoRepo: pr}
+}
+
+// GetPromos godoc
+// @Summary Get promos
+// @Description Retrieve all promo codes with their current usage
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} dtos.Response{data=[]models.Promo} "Promos retrieved successfully"
+// @Failure 500 {object} dtos.Response "Failed to fetch promos"
+// @Router /admin/promos [get]
+// @Security BearerAuth
+func (ph *PromoHandler) GetPromos(ctx *gin.Context) {
+	promos, err := ph.promoRepo.GetPromos(ctx.Request.Context())
+	if err != nil {
+		log.Println("GetPromos error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch promos",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    promos,
+	})
+}
+
+// GetPromo godoc
+// @Summary Get promo by ID
+// @Description Retrieve a promo code by ID
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Promo ID"
+// @Success 200 {object} dtos.Response{data=models.Promo} "Promo retrieved successfully"
+// @Failure 404 {object} dtos.Response "Promo not found"
+// @Failure 500 {object} dtos.Response "Failed to fetch promo"
+// @Router /admin/promos/{id} [get]
+// @Security BearerAuth
+func (ph *PromoHandler) GetPromo(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	promo, err := ph.promoRepo.GetPromo(ctx.Request.Context(), id)
+	if err != nil {
+		ph.respondPromoError(ctx, err, "Failed to fetch promo")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    promo,
+	})
+}
+
+// CreatePromo godoc
+// @Summary Create promo
+// @Description Create a percentage or fixed promo code with optional validity dates, usage caps and movie/cinema restrictions
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param promo body dtos.PromoRequest true "Promo data"
+// @Success 201 {object} dtos.Response{data=models.Promo} "Promo created successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data"
+// @Failure 409 {object} dtos.Response "Promo code already exists"
+// @Failure 500 {object} dtos.Response "Failed to create promo"
+// @Router /admin/promos [post]
+// @Security BearerAuth
+func (ph *PromoHandler) CreatePromo(ctx *gin.Context) {
+	var body dtos.PromoRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	promo := &models.Promo{
+		Code:          strings.ToUpper(strings.TrimSpace(body.Code)),
+		Description:   body.Description,
+		DiscountType:  models.PromoType(body.DiscountType),
+		DiscountValue: body.DiscountValue,
+		MaxDiscount:   body.MaxDiscount,
+		MinTickets:    max(body.MinTickets, 1),
+		ValidFrom:     body.ValidFrom,
+		ValidUntil:    body.ValidUntil,
+		UsageLimit:    body.UsageLimit,
+		PerUserLimit:  body.PerUserLimit,
+		IsActive:      body.IsActive == nil || *body.IsActive,
+		MovieIDs:      body.MovieIDs,
+		CinemaIDs:     body.CinemaIDs,
+	}
+	if msg := validatePromo(promo); msg != "" {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
+
+	if err := ph.promoRepo.CreatePromo(ctx.Request.Context(), promo); err != nil {
+		ph.respondPromoError(ctx, err, "Failed to create promo")
+		return
+	}
+	if promo.MovieIDs == nil {
+		promo.MovieIDs = []int{}
+	}
+	if promo.CinemaIDs == nil {
+		promo.CinemaIDs = []int{}
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.Response{
+		Code:    http.StatusCreated,
+		Success: true,
+		Message: "Promo created successfully",
+		Data:    promo,
+	})
+}
+
+// UpdatePromo godoc
+// @Summary Update promo
+// @Description Update a promo code. Only the fields sent are changed, an empty movie_ids or cinema_ids list removes the restriction. Optional limits are removed by listing them in clear, e.g. ["max_discount","valid_until"].
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Promo ID"
+// @Param promo body dtos.UpdatePromoRequest true "Promo data"
+// @Success 200 {object} dtos.Response{data=models.Promo} "Promo updated successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data"
+// @Failure 404 {object} dtos.Response "Promo not found"
+// @Failure 409 {object} dtos.Response "Promo code already exists"
+// @Failure 500 {object} dtos.Response "Failed to update promo"
+// @Router /admin/promos/{id} [patch]
+// @Security BearerAuth
+func (ph *PromoHandler) UpdatePromo(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var body dtos.UpdatePromoRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	promo, err := ph.promoRepo.GetPromo(ctx.Request.Context(), id)
+	if err != nil {
+		ph.respondPromoError(ctx, err, "Failed to update promo")
+		return
+	}
+
+	// kolom yang berubah diterapkan ke promo lama dulu supaya validasinya memakai nilai akhir
+	fields := map[string]any{}
+	if body.Code != nil {
+		promo.Code = strings.ToUpper(strings.TrimSpace(*body.Code))
+		fields["code"] = promo.Code
+	}
+	if body.Description != nil {
+		promo.Description = body.Description
+		fields["description"] = *body.Description
+	}
+	if body.DiscountType != nil {
+		promo.DiscountType = models.PromoType(*body.DiscountType)
+		fields["discount_type"] = string(promo.DiscountType)
+	}
+	if body.DiscountValue != nil {
+		promo.DiscountValue = *body.DiscountValue
+		fields["discount_value"] = promo.DiscountValue
+	}
+	if body.MaxDiscount != nil {
+		promo.MaxDiscount = body.MaxDiscount
+		fields["max_discount"] = *body.MaxDiscount
+	}
+	if body.MinTickets != nil {
+		promo.MinTickets = max(*body.MinTickets, 1)
+		fields["min_tickets"] = promo.MinTickets
+	}
+	if body.ValidFrom != nil {
+		promo.ValidFrom = body.ValidFrom
+		fields["valid_from"] = *body.ValidFrom
+	}
+	if body.ValidUntil != nil {
+		promo.ValidUntil = body.ValidUntil
+		fields["valid_until"] = *body.ValidUntil
+	}
+	if body.UsageLimit != nil {
+		promo.UsageLimit = body.UsageLimit
+		fields["usage_limit"] = *body.UsageLimit
+	}
+	if body.PerUserLimit != nil {
+		promo.PerUserLimit = body.PerUserLimit
+		fields["per_user_limit"] = *body.PerUserLimit
+	}
+	if body.IsActive != nil {
+		promo.IsActive = *body.IsActive
+		fields["is_active"] = promo.IsActive
+	}
+	for _, column := range body.Clear {
+		if _, set := fields[column]; set {
+			ctx.JSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: column + " cannot be set and cleared at the same time",
+			})
+			return
+		}
+		clearPromoField(promo, column)
+		fields[column] = nil
+	}
+	if msg := validatePromo(promo); msg != "" {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
+
+	if err := ph.promoRepo.UpdatePromo(ctx.Request.Context(), id, fields, body.MovieIDs, body.CinemaIDs); err != nil {
+		ph.respondPromoError(ctx, err, "Failed to update promo")
+		return
+	}
+
+	updated, err := ph.promoRepo.GetPromo(ctx.Request.Context(), id)
+	if err != nil {
+		ph.respondPromoError(ctx, err, "Failed to update promo")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Promo updated successfully",
+		Data:    updated,
+	})
+}
+
+// DeletePromo godoc
+// @Summary Delete promo
+// @Description Delete a promo code. Orders that already used the code keep their discount.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Promo ID"
+// @Success 200 {object} dtos.Response "Promo deleted successfully"
+// @Failure 404 {object} dtos.Response "Promo not found"
+// @Failure 500 {object} dtos.Response "Failed to delete promo"
+// @Router /admin/promos/{id} [delete]
+// @Security BearerAuth
+func (ph *PromoHandler) DeletePromo(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if err := ph.promoRepo.DeletePromo(ctx.Request.Context(), id); err != nil {
+		ph.respondPromoError(ctx, err, "Failed to delete promo")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Promo deleted successfully",
+	})
+}
+
+func (ph *PromoHandler) respondPromoError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repos.ErrPromoNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Promo not found",
+		})
+	case errors.Is(err, repos.ErrPromoCodeExists):
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: "Promo code already exists",
+		})
+	case errors.Is(err, repos.ErrPromoScope):
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Unknown movie or cinema in movie_ids / cinema_ids",
+		})
+	default:
+		log.Println("promo error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: message,
+		})
+	}
+}
+
+// validatePromo mengembalikan pesan error kalau isi promo tidak masuk akal
+// clearPromoField mengosongkan kolom opsional promo sesuai nama kolom di dtos.UpdatePromoRequest.Clear
+func clearPromoField(p *models.Promo, column string) {
+	switch column {
+	case "description":
+		p.Description = nil
+	case "max_discount":
+		p.MaxDiscount = nil
+	case "valid_from":
+		p.ValidFrom = nil
+	case "valid_until":
+		p.ValidUntil = nil
+	case "usage_limit":
+		p.UsageLimit = nil
+	case "per_user_limit":
+		p.PerUserLimit = nil
+	}
+}
+
+func validatePromo(p *models.Promo) string {
+	switch {
+	case p.Code == "":
+		return "Promo code is required"
+	case p.DiscountType == models.PromoPercent && p.DiscountValue > 100:
+		return "Percentage discount cannot be more than 100"
+	case p.ValidFrom != nil && p.ValidUntil != nil && !p.ValidUntil.After(*p.ValidFrom):
+		return "valid_until must be after valid_from"
+	case p.UsageLimit != nil && p.PerUserLimit != nil && *p.PerUserLimit > *p.UsageLimit:
+		return "per_user_limit cannot be more than usage_limit"
+	}
+	return ""
+}
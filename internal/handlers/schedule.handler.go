@@ -0,0 +1,731 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/payments"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// interval komentar keep-alive supaya proxy tidak memutus stream yang sepi
+const seatStreamHeartbeat = 15 * time.Second
+
+// batas schedule berulang supaya satu request tidak mengunci tabel schedules terlalu lama
+const (
+	maxRecurringRange     = 366 * 24 * time.Hour
+	maxRecurringSchedules = 1000
+)
+
+type ScheduleHandler struct {
+	scheduleRepo *repos.ScheduleRepo
+	orderRepo    *repos.OrderRepo
+	seatHoldRepo *repos.SeatHoldRepo
+	seatEvents   *repos.SeatEventRepo
+	paymentRepo  *repos.PaymentRepo
+	providers    *payments.Registry
+}
+
+func NewScheduleHandler(sr *repos.ScheduleRepo, or *repos.OrderRepo, shr *repos.SeatHoldRepo, ser *repos.SeatEventRepo, pr *repos.PaymentRepo, providers *payments.Registry) *ScheduleHandler {
+	return &ScheduleHandler{scheduleRepo: sr, orderRepo: or, seatHoldRepo: shr, seatEvents: ser, paymentRepo: pr, providers: providers}
+}
+
+// StreamSeats godoc
+// @Summary Stream seat availability
+// @Description Server-Sent Events stream of seat status changes for a schedule. The first event is "snapshot" with the current seat map, followed by "held", "released" and "sold" events carrying the affected seat ids. Events from every server instance are delivered.
+// @Tags Schedules
+// @Produce text/event-stream
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} models.SeatEvent "Seat events"
+// @Failure 400 {object} dtos.Response "Invalid schedule ID"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 500 {object} dtos.Response "Failed to open seat stream"
+// @Router /schedules/{id}/seats/stream [get]
+func (sh *ScheduleHandler) StreamSeats(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid schedule ID",
+		})
+		return
+	}
+
+	// subscribe dulu sebelum snapshot supaya tidak ada event yang terlewat di antaranya
+	events, unsubscribe := sh.seatEvents.Subscribe(scheduleID)
+	defer unsubscribe()
+
+	seatMap, err := sh.orderRepo.GetSeatMap(ctx.Request.Context(), scheduleID)
+	if errors.Is(err, repos.ErrScheduleNotFound) {
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Schedule not found",
+		})
+		return
+	}
+	if err == nil {
+		held, heldErr := sh.seatHoldRepo.GetHeldSeats(ctx.Request.Context(), scheduleID)
+		if heldErr == nil {
+			seatMap.ApplyHolds(held, uuid.Nil)
+		}
+		err = heldErr
+	}
+	if err != nil {
+		log.Println("StreamSeats error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to open seat stream",
+		})
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.SSEvent("snapshot", seatMap)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(seatStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case event := <-events:
+			ctx.SSEvent(string(event.Type), event)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+		}
+		return true
+	})
+}
+
+// BrowseSchedules godoc
+// @Summary Browse showtimes
+// @Description Find showtimes by movie, location, cinema and date range. Results are grouped by date, then cinema, and every showtime includes the number of seats still for sale. Seats that are only held are counted as remaining. Showtimes that have already started are never returned. starts_at and ends_at are in the cinema's local timezone.
+// @Tags Schedules
+// @Produce json
+// @Param movie_id query int false "Movie ID"
+// @Param location_id query int false "Location ID"
+// @Param cinema_id query int false "Cinema ID"
+// @Param date_from query string false "First date (YYYY-MM-DD)"
+// @Param date_to query string false "Last date (YYYY-MM-DD)"
+// @Success 200 {object} dtos.Response{data=[]models.ShowtimeDate} "Showtimes retrieved successfully"
+// @Failure 400 {object} dtos.Response "Invalid filter"
+// @Failure 500 {object} dtos.Response "Failed to fetch showtimes"
+// @Router /schedules [get]
+func (sh *ScheduleHandler) BrowseSchedules(ctx *gin.Context) {
+	filter := models.ScheduleFilter{Status: "active"}
+	filter.MovieID, _ = strconv.Atoi(ctx.Query("movie_id"))
+	filter.LocationID, _ = strconv.Atoi(ctx.Query("location_id"))
+	filter.CinemaID, _ = strconv.Atoi(ctx.Query("cinema_id"))
+
+	var err error
+	if filter.DateFrom, err = parseDateQuery(ctx, "date_from"); err == nil {
+		filter.DateTo, err = parseDateQuery(ctx, "date_to")
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid date, use YYYY-MM-DD",
+		})
+		return
+	}
+
+	// jadwal yang jam mulainya sudah lewat tidak ditampilkan
+	filter.Upcoming = true
+
+	schedules, err := sh.scheduleRepo.GetSchedules(ctx.Request.Context(), filter)
+	if err != nil {
+		log.Println("BrowseSchedules error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch showtimes",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    models.GroupShowtimes(schedules),
+	})
+}
+
+// GetSchedules godoc
+// @Summary Get schedules
+// @Description Retrieve schedules with the number of sold seats, optionally filtered by movie, cinema, location, hall, date range and status
+// @Tags Admin
+// @Produce json
+// @Param movie_id query int false "Movie ID"
+// @Param cinema_id query int false "Cinema ID"
+// @Param location_id query int false "Location ID"
+// @Param hall_id query int false "Hall ID"
+// @Param date_from query string false "First date (YYYY-MM-DD)"
+// @Param date_to query string false "Last date (YYYY-MM-DD)"
+// @Param status query string false "active or cancelled, empty for both" Enums(active, cancelled)
+// @Success 200 {object} dtos.Response{data=[]models.ScheduleDetail} "Schedules retrieved successfully"
+// @Failure 400 {object} dtos.Response "Invalid filter"
+// @Failure 500 {object} dtos.Response "Failed to fetch schedules"
+// @Router /admin/schedules [get]
+// @Security BearerAuth
+func (sh *ScheduleHandler) GetSchedules(ctx *gin.Context) {
+	filter := models.ScheduleFilter{Status: ctx.Query("status")}
+	filter.MovieID, _ = strconv.Atoi(ctx.Query("movie_id"))
+	filter.CinemaID, _ = strconv.Atoi(ctx.Query("cinema_id"))
+	filter.LocationID, _ = strconv.Atoi(ctx.Query("location_id"))
+	filter.HallID, _ = strconv.Atoi(ctx.Query("hall_id"))
+
+	var err error
+	if filter.DateFrom, err = parseDateQuery(ctx, "date_from"); err == nil {
+		filter.DateTo, err = parseDateQuery(ctx, "date_to")
+	}
+	if err != nil || (filter.Status != "" && filter.Status != "active" && filter.Status != "cancelled") {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid filter, dates use YYYY-MM-DD and status is active or cancelled",
+		})
+		return
+	}
+
+	schedules, err := sh.scheduleRepo.GetSchedules(ctx.Request.Context(), filter)
+	if err != nil {
+		log.Println("GetSchedules error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch schedules",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    schedules,
+	})
+}
+
+// GetSchedule godoc
+// @Summary Get schedule by ID
+// @Description Retrieve a schedule with the number of sold seats
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} dtos.Response{data=models.ScheduleDetail} "Schedule retrieved successfully"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 500 {object} dtos.Response "Failed to fetch schedule"
+// @Router /admin/schedules/{id} [get]
+// @Security BearerAuth
+func (sh *ScheduleHandler) GetSchedule(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	schedule, err := sh.scheduleRepo.GetSchedule(ctx.Request.Context(), id)
+	if err != nil {
+		respondScheduleError(ctx, err, "Failed to fetch schedule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    schedule,
+	})
+}
+
+// CreateSchedule godoc
+// @Summary Create schedule
+// @Description Create a single schedule for a movie. Without hall_id the movie plays in the cinema's first hall. The showtime plus the movie duration and the cleaning buffer may not overlap other schedules in the same hall.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param schedule body dtos.CreateScheduleRequest true "Schedule data"
+// @Success 201 {object} dtos.Response{data=models.ScheduleDetail} "Schedule created successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data, unknown movie, cinema, location, time or hall"
+// @Failure 404 {object} dtos.Response "Hall not found"
+// @Failure 409 {object} dtos.Response{data=[]models.ScheduleConflict} "Showtime overlaps other schedules in the hall"
+// @Failure 500 {object} dtos.Response "Failed to create schedule"
+// @Router /admin/schedules [post]
+// @Security BearerAuth
+func (sh *ScheduleHandler) CreateSchedule(ctx *gin.Context) {
+	var body dtos.CreateScheduleRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	schedule, err := scheduleFromRequest(body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	schedules := []models.Schedule{schedule}
+	if err := sh.scheduleRepo.CreateSchedules(ctx.Request.Context(), schedules); err != nil {
+		respondScheduleError(ctx, err, "Failed to create schedule")
+		return
+	}
+
+	created, err := sh.scheduleRepo.GetSchedule(ctx.Request.Context(), schedules[0].ID)
+	if err != nil {
+		respondScheduleError(ctx, err, "Failed to create schedule")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.Response{
+		Code:    http.StatusCreated,
+		Success: true,
+		Message: "Schedule created successfully",
+		Data:    created,
+	})
+}
+
+// BulkCreateSchedules godoc
+// @Summary Create schedules in bulk
+// @Description Create several schedules at once. Either every schedule is created or none is.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param schedules body dtos.BulkScheduleRequest true "Schedules"
+// @Success 201 {object} dtos.Response{data=[]models.Schedule} "Schedules created successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data, unknown movie, cinema, location, time or hall"
+// @Failure 404 {object} dtos.Response "Hall not found"
+// @Failure 409 {object} dtos.Response{data=[]models.ScheduleConflict} "Showtime overlaps other schedules in the hall or in the same request"
+// @Failure 500 {object} dtos.Response "Failed to create schedules"
+// @Router /admin/schedules/bulk [post]
+// @Security BearerAuth
+func (sh *ScheduleHandler) BulkCreateSchedules(ctx *gin.Context) {
+	var body dtos.BulkScheduleRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	schedules := make([]models.Schedule, 0, len(body.Schedules))
+	for i, req := range body.Schedules {
+		schedule, err := scheduleFromRequest(req)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: fmt.Sprintf("schedules[%d]: %s", i, err),
+			})
+			return
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := sh.scheduleRepo.CreateSchedules(ctx.Request.Context(), schedules); err != nil {
+		respondScheduleError(ctx, err, "Failed to create schedules")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.Response{
+		Code:    http.StatusCreated,
+		Success: true,
+		Message: "Schedules created successfully",
+		Data:    schedules,
+	})
+}
+
+// CreateRecurringSchedules godoc
+// @Summary Create recurring schedules
+// @Description Expand a recurrence rule into schedules, one for every date in the range that falls on one of the weekdays, for every cinema and time slot. Everything is saved in one transaction. With dry_run the generated showtimes are only previewed. Showtimes that overlap other schedules in the hall are flagged with their conflicts, and if any is flagged nothing is saved.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param rule body dtos.RecurringScheduleRequest true "Recurrence rule"
+// @Success 200 {object} dtos.Response{data=dtos.RecurringScheduleResponse} "Schedules previewed successfully"
+// @Success 201 {object} dtos.Response{data=dtos.RecurringScheduleResponse} "Schedules created successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data, unknown movie, cinema, location, time or hall"
+// @Failure 404 {object} dtos.Response "Hall not found"
+// @Failure 409 {object} dtos.Response{data=dtos.RecurringScheduleResponse} "Some showtimes overlap other schedules"
+// @Failure 500 {object} dtos.Response "Failed to create schedules"
+// @Router /admin/schedules/recurring [post]
+// @Security BearerAuth
+func (sh *ScheduleHandler) CreateRecurringSchedules(ctx *gin.Context) {
+	var body dtos.RecurringScheduleRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	rule, err := recurrenceFromRequest(body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var schedules []models.Schedule
+	for _, date := range rule.Dates() {
+		for _, c := range body.Cinemas {
+			for _, tid := range body.TimeIDs {
+				s := models.Schedule{
+					MovieID:    body.MovieID,
+					CinemaID:   c.CinemaID,
+					LocationID: c.LocationID,
+					TimeID:     tid,
+					Date:       date,
+					Price:      c.Price,
+				}
+				if c.HallID != nil {
+					s.HallID = *c.HallID
+				}
+				schedules = append(schedules, s)
+			}
+		}
+	}
+	if len(schedules) == 0 || len(schedules) > maxRecurringSchedules {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: fmt.Sprintf("The rule must generate between 1 and %d schedules, it generates %d", maxRecurringSchedules, len(schedules)),
+		})
+		return
+	}
+
+	previews, err := sh.scheduleRepo.CreateRecurringSchedules(ctx.Request.Context(), schedules, body.DryRun)
+	var conflictErr *repos.ScheduleConflictError
+	if err != nil && !errors.As(err, &conflictErr) {
+		respondScheduleError(ctx, err, "Failed to create schedules")
+		return
+	}
+
+	result := dtos.RecurringScheduleResponse{
+		DryRun:    body.DryRun,
+		Total:     len(previews),
+		Schedules: previews,
+	}
+	for _, p := range previews {
+		if len(p.Conflicts) > 0 {
+			result.Conflicts++
+		}
+	}
+
+	code, message := http.StatusCreated, "Schedules created successfully"
+	switch {
+	case conflictErr != nil:
+		code, message = http.StatusConflict, fmt.Sprintf("%d of %d showtimes overlap other schedules, nothing was saved", result.Conflicts, result.Total)
+	case body.DryRun:
+		code, message = http.StatusOK, "Schedules previewed successfully"
+	}
+	ctx.JSON(code, dtos.Response{
+		Code:    code,
+		Success: conflictErr == nil,
+		Message: message,
+		Data:    result,
+	})
+}
+
+// UpdateSchedule godoc
+// @Summary Update schedule
+// @Description Update a schedule. Only the fields sent are changed. The hall cannot be changed once seats have been sold, and changing the cinema without hall_id moves the schedule to the new cinema's first hall.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Param schedule body dtos.UpdateScheduleRequest true "Schedule data"
+// @Success 200 {object} dtos.Response{data=models.ScheduleDetail} "Schedule updated successfully"
+// @Failure 400 {object} dtos.Response "Invalid request data, unknown cinema, location, time or hall"
+// @Failure 404 {object} dtos.Response "Schedule or hall not found"
+// @Failure 409 {object} dtos.Response{data=[]models.ScheduleConflict} "Schedule is cancelled, already has sold seats or overlaps other schedules in the hall"
+// @Failure 500 {object} dtos.Response "Failed to update schedule"
+// @Router /admin/schedules/{id} [patch]
+// @Security BearerAuth
+func (sh *ScheduleHandler) UpdateSchedule(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	var body dtos.UpdateScheduleRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request data",
+		})
+		return
+	}
+
+	current, err := sh.scheduleRepo.GetSchedule(ctx.Request.Context(), id)
+	if err != nil {
+		respondScheduleError(ctx, err, "Failed to update schedule")
+		return
+	}
+
+	schedule := current.Schedule
+	if body.CinemaID != nil && *body.CinemaID != schedule.CinemaID {
+		schedule.CinemaID = *body.CinemaID
+		schedule.HallID = 0
+	}
+	if body.HallID != nil {
+		schedule.HallID = *body.HallID
+	}
+	if body.LocationID != nil {
+		schedule.LocationID = *body.LocationID
+	}
+	if body.TimeID != nil {
+		schedule.TimeID = *body.TimeID
+	}
+	if body.Price != nil {
+		schedule.Price = *body.Price
+	}
+	if body.Date != nil {
+		date, err := time.Parse("2006-01-02", *body.Date)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: "Invalid date, use YYYY-MM-DD",
+			})
+			return
+		}
+		schedule.Date = date
+	}
+
+	if err := sh.scheduleRepo.UpdateSchedule(ctx.Request.Context(), &schedule); err != nil {
+		respondScheduleError(ctx, err, "Failed to update schedule")
+		return
+	}
+
+	updated, err := sh.scheduleRepo.GetSchedule(ctx.Request.Context(), id)
+	if err != nil {
+		respondScheduleError(ctx, err, "Failed to update schedule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Schedule updated successfully",
+		Data:    updated,
+	})
+}
+
+// CancelSchedule godoc
+// @Summary Cancel schedule
+// @Description Cancel a schedule so it can no longer be booked. Seat holds are dropped and the seats are released to live seat pickers. Unpaid orders are cancelled, paid orders are refunded in full through their payment provider. Refunds the provider rejects stay pending in /admin/refunds so they can be retried.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} dtos.Response{data=dtos.CancelScheduleResponse} "Schedule cancelled successfully"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response "Schedule is already cancelled"
+// @Failure 500 {object} dtos.Response "Failed to cancel schedule"
+// @Router /admin/schedules/{id}/cancel [post]
+// @Security BearerAuth
+func (sh *ScheduleHandler) CancelSchedule(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	result, err := sh.scheduleRepo.CancelSchedule(ctx.Request.Context(), id)
+	if err != nil {
+		respondScheduleError(ctx, err, "Failed to cancel schedule")
+		return
+	}
+
+	// hold di redis tidak lagi berguna, kursinya ikut dilepas ke seat picker
+	seatIDs := result.SeatIDs
+	held, err := sh.seatHoldRepo.ClearHolds(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println("ClearHolds error:", err)
+	}
+	seatIDs = append(seatIDs, held...)
+	slices.Sort(seatIDs)
+	if seatIDs = slices.Compact(seatIDs); len(seatIDs) > 0 {
+		publishSeatEvent(ctx.Request.Context(), sh.seatEvents, models.NewSeatEvent(models.SeatEventReleased, id, seatIDs))
+	}
+
+	// refund yang gagal tetap pending dan bisa diulang lewat /admin/refunds
+	refunds := make([]*models.Refund, 0, len(result.Refunds))
+	for _, refund := range result.Refunds {
+		processed, err := processRefund(ctx.Request.Context(), sh.paymentRepo, sh.providers, refund)
+		if err != nil {
+			log.Println("RecordRefundAttempt error:", err)
+			processed = refund
+		}
+		refunds = append(refunds, processed)
+	}
+
+	schedule, err := sh.scheduleRepo.GetSchedule(ctx.Request.Context(), id)
+	if err != nil {
+		respondScheduleError(ctx, err, "Failed to cancel schedule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Schedule cancelled successfully",
+		Data: dtos.CancelScheduleResponse{
+			Schedule:        schedule,
+			CancelledOrders: result.CancelledOrders,
+			RefundedOrders:  result.RefundedOrders,
+			Refunds:         refunds,
+		},
+	})
+}
+
+// DeleteSchedule godoc
+// @Summary Delete schedule
+// @Description Delete a schedule that has never been ordered. Schedules with orders have to be cancelled instead.
+// @Tags Admin
+// @Produce json
+// @Param id path int true "Schedule ID"
+// @Success 200 {object} dtos.Response "Schedule deleted successfully"
+// @Failure 404 {object} dtos.Response "Schedule not found"
+// @Failure 409 {object} dtos.Response "Schedule already has orders"
+// @Failure 500 {object} dtos.Response "Failed to delete schedule"
+// @Router /admin/schedules/{id} [delete]
+// @Security BearerAuth
+func (sh *ScheduleHandler) DeleteSchedule(ctx *gin.Context) {
+	id, _ := strconv.Atoi(ctx.Param("id"))
+	if err := sh.scheduleRepo.DeleteSchedule(ctx.Request.Context(), id); err != nil {
+		respondScheduleError(ctx, err, "Failed to delete schedule")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: "Schedule deleted successfully",
+	})
+}
+
+func respondScheduleError(ctx *gin.Context, err error, message string) {
+	if respondScheduleConflict(ctx, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, repos.ErrScheduleReference), errors.Is(err, repos.ErrScheduleHall), errors.Is(err, repos.ErrScheduleTime):
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: err.Error(),
+		})
+	case errors.Is(err, repos.ErrScheduleNotFound), errors.Is(err, repos.ErrHallNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: err.Error(),
+		})
+	case errors.Is(err, repos.ErrScheduleInUse), errors.Is(err, repos.ErrScheduleCancelled):
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: err.Error(),
+		})
+	default:
+		log.Println("schedule error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: message,
+		})
+	}
+}
+
+// respondScheduleConflict menjawab 409 beserta schedule yang bentrok, false kalau err bukan bentrok jadwal
+func respondScheduleConflict(ctx *gin.Context, err error) bool {
+	var conflictErr *repos.ScheduleConflictError
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+	ctx.JSON(http.StatusConflict, dtos.Response{
+		Code:    http.StatusConflict,
+		Success: false,
+		Message: err.Error(),
+		Data:    conflictErr.Conflicts,
+	})
+	return true
+}
+
+// recurrenceFromRequest membaca rentang tanggal dan hari dari request schedule berulang
+func recurrenceFromRequest(req dtos.RecurringScheduleRequest) (models.RecurrenceRule, error) {
+	var rule models.RecurrenceRule
+	var err error
+	if rule.From, err = time.Parse("2006-01-02", req.StartDate); err != nil {
+		return rule, fmt.Errorf("invalid start_date %q, use YYYY-MM-DD", req.StartDate)
+	}
+	if rule.Until, err = time.Parse("2006-01-02", req.EndDate); err != nil {
+		return rule, fmt.Errorf("invalid end_date %q, use YYYY-MM-DD", req.EndDate)
+	}
+	if rule.Until.Before(rule.From) {
+		return rule, errors.New("end_date must not be before start_date")
+	}
+	if rule.Until.Sub(rule.From) > maxRecurringRange {
+		return rule, fmt.Errorf("the date range cannot be longer than %d days", int(maxRecurringRange.Hours()/24))
+	}
+	for _, name := range req.Weekdays {
+		day, err := models.ParseWeekday(name)
+		if err != nil {
+			return rule, err
+		}
+		rule.Weekdays = append(rule.Weekdays, day)
+	}
+	return rule, nil
+}
+
+// scheduleFromRequest mengubah request API schedule admin menjadi schedule
+func scheduleFromRequest(req dtos.CreateScheduleRequest) (models.Schedule, error) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return models.Schedule{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD", req.Date)
+	}
+	s := models.Schedule{
+		MovieID:    req.MovieID,
+		CinemaID:   req.CinemaID,
+		LocationID: req.LocationID,
+		TimeID:     req.TimeID,
+		Date:       date,
+		Price:      req.Price,
+	}
+	if req.HallID != nil {
+		s.HallID = *req.HallID
+	}
+	return s, nil
+}
+
+// parseDateQuery membaca query tanggal YYYY-MM-DD, nil kalau tidak dikirim
+func parseDateQuery(ctx *gin.Context, key string) (*time.Time, error) {
+	v := ctx.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
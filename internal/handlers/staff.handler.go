@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/Darari17/be-tickitz/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StaffHandler struct {
+	checkInRepo *repos.CheckInRepo
+	staffRepo   *repos.StaffRepo
+}
+
+func NewStaffHandler(cr *repos.CheckInRepo, sr *repos.StaffRepo) *StaffHandler {
+	return &StaffHandler{checkInRepo: cr, staffRepo: sr}
+}
+
+var checkInMessages = map[models.CheckInReason]string{
+	models.CheckInAccepted:     "Ticket accepted",
+	models.CheckInUnknownToken: "Unknown ticket",
+	models.CheckInAlreadyUsed:  "Ticket has already been used",
+	models.CheckInNotPaid:      "Ticket is not paid or no longer valid",
+	models.CheckInWrongCinema:  "Ticket is for another cinema",
+	models.CheckInWrongDay:     "Ticket is for another day",
+	models.CheckInNoSeats:      "Ticket has no seats left, they were transferred",
+}
+
+// CheckIn godoc
+// @Summary Check in a ticket
+// @Description Validate a scanned ticket QR token at the cinema door and mark its seats as checked in. A ticket can only be checked in once. Staff can only check in tickets for the cinema an admin assigned them to. Admins pass the cinema in cinema_id.
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param checkin body dtos.CheckInRequest true "Scanned token, cinema_id is only read for admins"
+// @Success 200 {object} dtos.Response{data=models.CheckInResult} "Ticket accepted"
+// @Failure 400 {object} dtos.Response "Invalid request payload"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 403 {object} dtos.Response "Staff is not assigned to a cinema"
+// @Failure 422 {object} dtos.Response{data=models.CheckInResult} "Ticket rejected, see data.reason"
+// @Failure 500 {object} dtos.Response "Failed to check in ticket"
+// @Router /staff/checkin [post]
+// @Security BearerAuth
+func (sh *StaffHandler) CheckIn(ctx *gin.Context) {
+	var req dtos.CheckInRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request payload",
+		})
+		return
+	}
+
+	userID, role, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	// cinema staff diambil dari penugasan di server, bukan dari request
+	cinemaID := req.CinemaID
+	if role != string(models.RoleAdmin) {
+		cinemaID, err = sh.staffRepo.GetCinemaID(ctx.Request.Context(), userID)
+		if err != nil {
+			if !errors.Is(err, repos.ErrStaffUnassigned) && !errors.Is(err, repos.ErrStaffNotFound) {
+				log.Println("GetCinemaID error:", err)
+			}
+			ctx.JSON(http.StatusForbidden, dtos.Response{
+				Code:    http.StatusForbidden,
+				Success: false,
+				Message: "Staff is not assigned to a cinema",
+			})
+			return
+		}
+	} else if cinemaID == 0 {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "cinema_id is required for admins",
+		})
+		return
+	}
+
+	result, err := sh.checkInRepo.CheckIn(ctx.Request.Context(), req.Token, cinemaID, time.Now())
+	if err != nil {
+		log.Println("CheckIn error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to check in ticket",
+		})
+		return
+	}
+
+	code := http.StatusOK
+	if !result.Accepted {
+		code = http.StatusUnprocessableEntity
+	}
+	ctx.JSON(code, dtos.Response{
+		Code:    code,
+		Success: result.Accepted,
+		Message: checkInMessages[result.Reason],
+		Data:    result,
+	})
+}
+
+// AssignCinema godoc
+// @Summary Assign staff to a cinema
+// @Description Set the cinema where a staff account checks in tickets. A registered user account is promoted to staff at the same time and gets staff access after logging in again. Admin and guest accounts cannot be assigned.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param assignment body dtos.AssignStaffCinemaRequest true "Cinema"
+// @Success 200 {object} dtos.Response "Staff assigned successfully"
+// @Failure 400 {object} dtos.Response "Invalid request payload or unknown cinema"
+// @Failure 404 {object} dtos.Response "User not found or cannot be staff"
+// @Failure 500 {object} dtos.Response "Failed to assign staff"
+// @Router /admin/staff/{id}/cinema [put]
+// @Security BearerAuth
+func (sh *StaffHandler) AssignCinema(ctx *gin.Context) {
+	userID, err := uuid.Parse(ctx.Param("id"))
+	var req dtos.AssignStaffCinemaRequest
+	if err == nil {
+		err = ctx.ShouldBindJSON(&req)
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request payload",
+		})
+		return
+	}
+
+	err = sh.staffRepo.AssignCinema(ctx.Request.Context(), userID, req.CinemaID)
+	switch {
+	case errors.Is(err, repos.ErrStaffCinemaUnknown):
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Cinema not found",
+		})
+	case errors.Is(err, repos.ErrStaffNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "User not found or cannot be staff",
+		})
+	case err != nil:
+		log.Println("AssignCinema error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to assign staff",
+		})
+	default:
+		ctx.JSON(http.StatusOK, dtos.Response{
+			Code:    http.StatusOK,
+			Success: true,
+			Message: "Staff assigned successfully",
+		})
+	}
+}
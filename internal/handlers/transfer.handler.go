@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/Darari17/be-tickitz/internal/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TransferHandler struct {
+	transferRepo *repos.TransferRepo
+}
+
+func NewTransferHandler(tr *repos.TransferRepo) *TransferHandler {
+	return &TransferHandler{transferRepo: tr}
+}
+
+// CreateTransfer godoc
+// @Summary Transfer order
+// @Description Offer a paid order, or some of its seats, to another registered user by email. The recipient must have a regular user account, guest, staff and admin accounts cannot receive tickets. The seats move once the recipient accepts.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param transfer body dtos.TransferRequest true "Recipient and seats, empty seat_codes transfers the whole order"
+// @Success 201 {object} dtos.Response{data=models.OrderTransfer} "Transfer created successfully"
+// @Failure 400 {object} dtos.Response "Invalid request payload, recipient or seats"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 404 {object} dtos.Response "Order not found"
+// @Failure 409 {object} dtos.Response "Order is not paid or already has a pending transfer"
+// @Failure 500 {object} dtos.Response "Failed to create transfer"
+// @Router /orders/{id}/transfers [post]
+// @Security BearerAuth
+func (th *TransferHandler) CreateTransfer(ctx *gin.Context) {
+	orderID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid order ID",
+		})
+		return
+	}
+
+	var body dtos.TransferRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid request payload",
+		})
+		return
+	}
+	for i, code := range body.SeatCodes {
+		body.SeatCodes[i] = strings.ToUpper(strings.TrimSpace(code))
+	}
+
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	transfer, err := th.transferRepo.CreateTransfer(ctx.Request.Context(), orderID, userID, body.Email, body.SeatCodes)
+	if err != nil {
+		respondTransferError(ctx, err, "Failed to create transfer")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.Response{
+		Code:    http.StatusCreated,
+		Success: true,
+		Message: "Transfer created successfully",
+		Data:    transfer,
+	})
+}
+
+// GetTransfers godoc
+// @Summary Get transfers
+// @Description Retrieve order transfers sent or received by the authenticated user
+// @Tags Orders
+// @Produce json
+// @Success 200 {object} dtos.Response{data=[]models.OrderTransfer} "Transfers retrieved successfully"
+// @Failure 401 {object} dtos.Response "Unauthorized"
+// @Failure 500 {object} dtos.Response "Failed to fetch transfers"
+// @Router /orders/transfers [get]
+// @Security BearerAuth
+func (th *TransferHandler) GetTransfers(ctx *gin.Context) {
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	transfers, err := th.transferRepo.GetTransfers(ctx.Request.Context(), userID)
+	if err != nil {
+		log.Println("GetTransfers error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: "Failed to fetch transfers",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Data:    transfers,
+	})
+}
+
+// AcceptTransfer godoc
+// @Summary Accept transfer
+// @Description Accept a transfer sent to the authenticated user. The seats move to a new order owned by the recipient and the ticket QR codes of both orders are re-issued, so the old QR code stops working. When every seat of the order is transferred, the sender's order is closed with status transferred and no longer has a ticket.
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} dtos.Response{data=models.OrderTransfer} "Transfer accepted successfully"
+// @Failure 400 {object} dtos.Response "Invalid transfer ID"
+// @Failure 404 {object} dtos.Response "Transfer not found"
+// @Failure 409 {object} dtos.Response "Transfer already answered or order no longer transferable"
+// @Failure 500 {object} dtos.Response "Failed to accept transfer"
+// @Router /orders/transfers/{id}/accept [post]
+// @Security BearerAuth
+func (th *TransferHandler) AcceptTransfer(ctx *gin.Context) {
+	th.answerTransfer(ctx, th.transferRepo.AcceptTransfer, "Transfer accepted successfully", "Failed to accept transfer")
+}
+
+// DeclineTransfer godoc
+// @Summary Decline transfer
+// @Description Decline a transfer sent to the authenticated user, the seats stay with the sender
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} dtos.Response{data=models.OrderTransfer} "Transfer declined successfully"
+// @Failure 400 {object} dtos.Response "Invalid transfer ID"
+// @Failure 404 {object} dtos.Response "Transfer not found"
+// @Failure 409 {object} dtos.Response "Transfer already answered"
+// @Failure 500 {object} dtos.Response "Failed to decline transfer"
+// @Router /orders/transfers/{id}/decline [post]
+// @Security BearerAuth
+func (th *TransferHandler) DeclineTransfer(ctx *gin.Context) {
+	th.answerTransfer(ctx, th.transferRepo.DeclineTransfer, "Transfer declined successfully", "Failed to decline transfer")
+}
+
+// CancelTransfer godoc
+// @Summary Cancel transfer
+// @Description Withdraw a pending transfer sent by the authenticated user
+// @Tags Orders
+// @Produce json
+// @Param id path int true "Transfer ID"
+// @Success 200 {object} dtos.Response{data=models.OrderTransfer} "Transfer cancelled successfully"
+// @Failure 400 {object} dtos.Response "Invalid transfer ID"
+// @Failure 404 {object} dtos.Response "Transfer not found"
+// @Failure 409 {object} dtos.Response "Transfer already answered"
+// @Failure 500 {object} dtos.Response "Failed to cancel transfer"
+// @Router /orders/transfers/{id}/cancel [post]
+// @Security BearerAuth
+func (th *TransferHandler) CancelTransfer(ctx *gin.Context) {
+	th.answerTransfer(ctx, th.transferRepo.CancelTransfer, "Transfer cancelled successfully", "Failed to cancel transfer")
+}
+
+type transferAction func(ctx context.Context, transferID int, userID uuid.UUID) (*models.OrderTransfer, error)
+
+func (th *TransferHandler) answerTransfer(ctx *gin.Context, action transferAction, success, failure string) {
+	transferID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: "Invalid transfer ID",
+		})
+		return
+	}
+
+	userID, _, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, dtos.Response{
+			Code:    http.StatusUnauthorized,
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	transfer, err := action(ctx.Request.Context(), transferID, userID)
+	if err != nil {
+		respondTransferError(ctx, err, failure)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.Response{
+		Code:    http.StatusOK,
+		Success: true,
+		Message: success,
+		Data:    transfer,
+	})
+}
+
+func respondTransferError(ctx *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, repos.ErrOrderNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Order not found",
+		})
+	case errors.Is(err, repos.ErrTransferNotFound):
+		ctx.JSON(http.StatusNotFound, dtos.Response{
+			Code:    http.StatusNotFound,
+			Success: false,
+			Message: "Transfer not found",
+		})
+	case errors.Is(err, repos.ErrTransferRecipient), errors.Is(err, repos.ErrTransferSelf), errors.Is(err, repos.ErrTransferSeats):
+		ctx.JSON(http.StatusBadRequest, dtos.Response{
+			Code:    http.StatusBadRequest,
+			Success: false,
+			Message: err.Error(),
+		})
+	case errors.Is(err, repos.ErrTransferPending), errors.Is(err, repos.ErrTransferNotAllowed), errors.Is(err, repos.ErrTransferClosed):
+		ctx.JSON(http.StatusConflict, dtos.Response{
+			Code:    http.StatusConflict,
+			Success: false,
+			Message: err.Error(),
+		})
+	default:
+		log.Println("transfer error:", err)
+		ctx.JSON(http.StatusInternalServerError, dtos.Response{
+			Code:    http.StatusInternalServerError,
+			Success: false,
+			Message: message,
+		})
+	}
+}
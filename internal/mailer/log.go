@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LogMailer untuk development. kalau dir diisi, setiap email disimpan sebagai
+// file .eml yang bisa dibuka di email client, kalau kosong hanya dicatat di log.
+type LogMailer struct {
+	from string
+	dir  string
+}
+
+func NewLogMailer(from, dir string) *LogMailer {
+	return &LogMailer{from: from, dir: dir}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg *Message) error {
+	if m.dir == "" {
+		log.Printf("mail to %s: %s\n%s\n", msg.To, msg.Subject, msg.Text)
+		return nil
+	}
+
+	body, err := Build(m.from, msg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	name := filepath.Join(m.dir, fmt.Sprintf("%d.eml", time.Now().UnixNano()))
+	if err := os.WriteFile(name, body, 0o644); err != nil {
+		return err
+	}
+	log.Printf("mail to %s saved to %s\n", msg.To, name)
+	return nil
+}
@@ -0,0 +1,144 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mailer mengirim email. implementasinya dipilih dari env lewat configs.InitMailer
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Inline adalah lampiran yang ditampilkan di dalam body HTML lewat cid:ContentID
+type Inline struct {
+	ContentID   string
+	ContentType string
+	Filename    string
+	Data        []byte
+}
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	Inlines []Inline
+}
+
+// Build menyusun pesan MIME multipart/alternative (text + html).
+// kalau ada lampiran inline, bagian html dibungkus multipart/related.
+func Build(from string, msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", from)
+	header.Set("To", msg.To)
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", fmt.Sprintf("<%s@%s>", uuid.NewString(), domainOf(from)))
+	header.Set("MIME-Version", "1.0")
+
+	alt := multipart.NewWriter(&buf)
+	header.Set("Content-Type", "multipart/alternative; boundary="+alt.Boundary())
+	writeHeader(&buf, header)
+
+	if err := writePart(alt, "text/plain; charset=utf-8", []byte(msg.Text)); err != nil {
+		return nil, err
+	}
+
+	if len(msg.Inlines) == 0 {
+		if err := writePart(alt, "text/html; charset=utf-8", []byte(msg.HTML)); err != nil {
+			return nil, err
+		}
+		if err := alt.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var related bytes.Buffer
+	rel := multipart.NewWriter(&related)
+	if err := writePart(rel, "text/html; charset=utf-8", []byte(msg.HTML)); err != nil {
+		return nil, err
+	}
+	for _, in := range msg.Inlines {
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Type", in.ContentType)
+		h.Set("Content-Transfer-Encoding", "base64")
+		h.Set("Content-ID", "<"+in.ContentID+">")
+		h.Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", in.Filename))
+		w, err := rel.CreatePart(h)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(w, in.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := rel.Close(); err != nil {
+		return nil, err
+	}
+
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Type", "multipart/related; boundary="+rel.Boundary())
+	w, err := alt.CreatePart(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(related.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := alt.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	for _, key := range []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type"} {
+		fmt.Fprintf(buf, "%s: %s\r\n", key, header.Get(key))
+	}
+	buf.WriteString("\r\n")
+}
+
+func writePart(w *multipart.Writer, contentType string, body []byte) error {
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Transfer-Encoding", "base64")
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	return writeBase64(part, body)
+}
+
+// writeBase64 menulis base64 dengan baris maksimal 76 karakter sesuai RFC 2045
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := fmt.Fprintf(w, "%s\r\n", encoded[:76]); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := fmt.Fprintf(w, "%s\r\n", encoded)
+	return err
+}
+
+func domainOf(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return strings.Trim(address[i+1:], "> ")
+	}
+	return "localhost"
+}
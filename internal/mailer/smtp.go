@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/mail"
+	"net/smtp"
+)
+
+// SMTPMailer mengirim lewat server SMTP. username boleh kosong untuk sink lokal
+// seperti MailHog atau Mailpit yang tidak butuh autentikasi.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	body, err := Build(m.from, msg)
+	if err != nil {
+		return err
+	}
+
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return err
+	}
+	recipient, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.addr, m.auth, sender.Address, []string{recipient.Address}, body)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
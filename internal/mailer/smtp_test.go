@@ -0,0 +1,262 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+type receivedMail struct {
+	from string
+	to   []string
+	data []byte
+}
+
+// startSMTPServer menjalankan server SMTP minimal di localhost yang menerima satu email
+func startSMTPServer(t *testing.T) (host, port string, received <-chan receivedMail) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan receivedMail, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		var m receivedMail
+		reply("220 localhost ESMTP test")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimRight(line, "\r\n")
+			switch verb := strings.ToUpper(strings.SplitN(cmd, " ", 2)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				m.from = addressOf(cmd)
+				reply("250 OK")
+			case "RCPT":
+				m.to = append(m.to, addressOf(cmd))
+				reply("250 OK")
+			case "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data bytes.Buffer
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(strings.TrimPrefix(line, "."))
+				}
+				m.data = data.Bytes()
+				reply("250 OK")
+			case "QUIT":
+				reply("221 Bye")
+				ch <- m
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	host, port, err = net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return host, port, ch
+}
+
+func addressOf(cmd string) string {
+	start, end := strings.Index(cmd, "<"), strings.Index(cmd, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return cmd[start+1 : end]
+}
+
+type mimePart struct {
+	contentType string
+	params      map[string]string
+	header      map[string][]string
+	body        []byte
+}
+
+func readParts(t *testing.T, body io.Reader, boundary string) []mimePart {
+	t.Helper()
+	var parts []mimePart
+	r := multipart.NewReader(body, boundary)
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		mediaType, params, err := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := io.ReadAll(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Header.Get("Content-Transfer-Encoding") == "base64" {
+			raw, err = base64.StdEncoding.DecodeString(strings.NewReplacer("\r", "", "\n", "").Replace(string(raw)))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		parts = append(parts, mimePart{contentType: mediaType, params: params, header: p.Header, body: raw})
+	}
+}
+
+func sendTestMail(t *testing.T, msg *Message) (receivedMail, *mail.Message) {
+	t.Helper()
+	host, port, received := startSMTPServer(t)
+
+	m := NewSMTPMailer(host, port, "", "", "Tickitz <no-reply@tickitz.test>")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Send(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	var got receivedMail
+	select {
+	case got = <-received:
+	case <-ctx.Done():
+		t.Fatal("smtp server did not receive the mail")
+	}
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(got.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got, parsed
+}
+
+func TestSMTPMailerSendWithInlineQR(t *testing.T) {
+	qr := []byte("\x89PNG\r\n\x1a\nfake-qr-image")
+	msg := &Message{
+		To:      "Budi <budi@example.com>",
+		Subject: "Your Tickitz ticket #1 - Dune",
+		Text:    "Show this QR code at the entrance",
+		HTML:    `<p>Show this QR code at the entrance</p><img src="cid:ticket-qr">`,
+		Inlines: []Inline{{ContentID: "ticket-qr", ContentType: "image/png", Filename: "ticket.png", Data: qr}},
+	}
+	got, parsed := sendTestMail(t, msg)
+
+	if got.from != "no-reply@tickitz.test" {
+		t.Fatalf("unexpected envelope sender %q", got.from)
+	}
+	if len(got.to) != 1 || got.to[0] != "budi@example.com" {
+		t.Fatalf("unexpected envelope recipients %v", got.to)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != msg.Subject {
+		t.Fatalf("expected subject %q, got %q", msg.Subject, subject)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative, got %s", mediaType)
+	}
+
+	alt := readParts(t, parsed.Body, params["boundary"])
+	if len(alt) != 2 {
+		t.Fatalf("expected 2 alternative parts, got %d", len(alt))
+	}
+	if alt[0].contentType != "text/plain" || string(alt[0].body) != msg.Text {
+		t.Fatalf("unexpected text part %s %q", alt[0].contentType, alt[0].body)
+	}
+	if alt[1].contentType != "multipart/related" {
+		t.Fatalf("expected html to be wrapped in multipart/related, got %s", alt[1].contentType)
+	}
+
+	related := readParts(t, bytes.NewReader(alt[1].body), alt[1].params["boundary"])
+	if len(related) != 2 {
+		t.Fatalf("expected html and qr parts, got %d", len(related))
+	}
+	if related[0].contentType != "text/html" || string(related[0].body) != msg.HTML {
+		t.Fatalf("unexpected html part %s %q", related[0].contentType, related[0].body)
+	}
+
+	img := related[1]
+	if img.contentType != "image/png" {
+		t.Fatalf("expected image/png, got %s", img.contentType)
+	}
+	if cid := img.header["Content-Id"]; len(cid) != 1 || cid[0] != "<ticket-qr>" {
+		t.Fatalf("unexpected Content-ID %v", cid)
+	}
+	disposition, dispParams, err := mime.ParseMediaType(img.header["Content-Disposition"][0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if disposition != "inline" || dispParams["filename"] != "ticket.png" {
+		t.Fatalf("unexpected disposition %s %v", disposition, dispParams)
+	}
+	if !bytes.Equal(img.body, qr) {
+		t.Fatal("qr image does not survive the round trip")
+	}
+}
+
+func TestSMTPMailerSendWithoutInlines(t *testing.T) {
+	msg := &Message{
+		To:      "budi@example.com",
+		Subject: "Complete your payment for Tickitz order #1 - Dune",
+		Text:    "Pay before 19:30",
+		HTML:    "<p>Pay before 19:30</p>",
+	}
+	_, parsed := sendTestMail(t, msg)
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative, got %s", mediaType)
+	}
+
+	alt := readParts(t, parsed.Body, params["boundary"])
+	if len(alt) != 2 {
+		t.Fatalf("expected 2 alternative parts, got %d", len(alt))
+	}
+	if alt[0].contentType != "text/plain" || string(alt[0].body) != msg.Text {
+		t.Fatalf("unexpected text part %s %q", alt[0].contentType, alt[0].body)
+	}
+	if alt[1].contentType != "text/html" || string(alt[1].body) != msg.HTML {
+		t.Fatalf("unexpected html part %s %q", alt[1].contentType, alt[1].body)
+	}
+}
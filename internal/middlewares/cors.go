@@ -24,7 +24,7 @@ func CORSMiddleware(ctx *gin.Context) {
 	}
 	// header untuk preflight cors
 	ctx.Header("Access-Control-Allow-Methods", "GET, POST, PATCH, PUT, DELETE, OPTIONS")
-	ctx.Header("Access-Control-Allow-Headers", "Authorization, Content-Type")
+	ctx.Header("Access-Control-Allow-Headers", "Authorization, Content-Type, Idempotency-Key")
 	// tangani apabila bertemu preflight
 	if ctx.Request.Method == http.MethodOptions {
 		// ctx.Header("X-DEBUG", "preflight-handled")
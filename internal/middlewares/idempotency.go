@@ -0,0 +1,131 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/Darari17/be-tickitz/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// bodyRecorder menyalin response supaya bisa disimpan untuk retry
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency membuat retry dengan Idempotency-Key yang sama mendapat response pertama tanpa memproses ulang.
+// key berlaku per user, payload berbeda dengan key yang sama ditolak 422.
+func Idempotency(repo *repos.IdempotencyRepo) func(*gin.Context) {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			ctx.Next()
+			return
+		}
+		if len(key) > 255 {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: "Idempotency-Key is too long",
+			})
+			return
+		}
+
+		userID, _, err := utils.GetUserFromContext(ctx)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, dtos.Response{
+				Code:    http.StatusUnauthorized,
+				Success: false,
+				Message: "Unauthorized",
+			})
+			return
+		}
+
+		payload, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, dtos.Response{
+				Code:    http.StatusBadRequest,
+				Success: false,
+				Message: "Invalid request payload",
+			})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(payload))
+
+		sum := sha256.Sum256(append([]byte(ctx.Request.Method+" "+ctx.FullPath()+"\n"), payload...))
+		fingerprint := hex.EncodeToString(sum[:])
+		scope := userID.String()
+
+		record, err := repo.Begin(ctx.Request.Context(), scope, key, fingerprint)
+		switch {
+		case errors.Is(err, repos.ErrIdempotencyMismatch):
+			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, dtos.Response{
+				Code:    http.StatusUnprocessableEntity,
+				Success: false,
+				Message: "Idempotency-Key was already used with a different request",
+			})
+			return
+		case errors.Is(err, repos.ErrIdempotencyInProgress):
+			ctx.AbortWithStatusJSON(http.StatusConflict, dtos.Response{
+				Code:    http.StatusConflict,
+				Success: false,
+				Message: "A request with this Idempotency-Key is still being processed",
+			})
+			return
+		case err != nil:
+			log.Println("Idempotency error:", err)
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, dtos.Response{
+				Code:    http.StatusInternalServerError,
+				Success: false,
+				Message: "Internal Server Error",
+			})
+			return
+		case record != nil:
+			ctx.Header("Idempotent-Replayed", "true")
+			ctx.Data(record.StatusCode, record.ContentType, record.Body)
+			ctx.Abort()
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		// error server tidak disimpan supaya client bisa mencoba lagi dengan key yang sama
+		if recorder.Status() >= http.StatusInternalServerError {
+			if err := repo.Release(ctx.Request.Context(), scope, key); err != nil {
+				log.Println("Idempotency release error:", err)
+			}
+			return
+		}
+		if err := repo.Complete(ctx.Request.Context(), scope, key, &models.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}); err != nil {
+			log.Println("Idempotency complete error:", err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/dtos"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit membatasi jumlah request per IP client untuk satu endpoint.
+// kalau redis bermasalah request tetap dilayani supaya endpoint tidak ikut mati.
+func RateLimit(repo *repos.RateLimitRepo, name string, limit int, window time.Duration) func(*gin.Context) {
+	return func(ctx *gin.Context) {
+		allowed, retryAfter, err := repo.Allow(ctx.Request.Context(), name+":"+ctx.ClientIP(), limit, window)
+		if err != nil {
+			log.Println("RateLimit error:", err)
+			ctx.Next()
+			return
+		}
+		if !allowed {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, dtos.Response{
+				Code:    http.StatusTooManyRequests,
+				Success: false,
+				Message: "Too many requests, please try again later",
+			})
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,216 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// karakter layout studio, satu karakter untuk satu kolom grid
+const (
+	LayoutRegular    = 'S'
+	LayoutVIP        = 'V'
+	LayoutCouple     = 'C'
+	LayoutWheelchair = 'W'
+	LayoutGap        = '_'
+)
+
+var layoutSeatTypes = map[rune]string{
+	LayoutRegular:    "regular",
+	LayoutVIP:        "vip",
+	LayoutCouple:     "couple",
+	LayoutWheelchair: "wheelchair",
+}
+
+// Hall adalah studio di sebuah cinema dengan grid kursinya sendiri
+type Hall struct {
+	ID        int        `db:"id" json:"id"`
+	CinemaID  int        `db:"cinemas_id" json:"cinema_id"`
+	Name      string     `db:"name" json:"name"`
+	Rows      int        `db:"row_count" json:"rows"`
+	Columns   int        `db:"column_count" json:"columns"`
+	Layout    []string   `db:"-" json:"layout,omitempty"`
+	Seats     []Seat     `db:"-" json:"seats,omitempty"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt *time.Time `db:"updated_at" json:"updated_at,omitempty"`
+}
+
+// ParseHallLayout mengubah layout per baris (mis. "SSS__SSS") menjadi daftar kursi.
+// baris diberi huruf A, B, C, ... dan nomor kursi dihitung dari kiri tanpa menghitung lorong.
+func ParseHallLayout(layout []string) (rows, columns int, seats []Seat, err error) {
+	if len(layout) == 0 || len(layout) > 26 {
+		return 0, 0, nil, fmt.Errorf("layout must have between 1 and 26 rows")
+	}
+
+	for i, line := range layout {
+		line = strings.ToUpper(strings.TrimRight(line, " "))
+		columns = max(columns, len(line))
+		rowLetter := string(rune('A' + i))
+		number := 0
+		for j, ch := range line {
+			if ch == LayoutGap || ch == ' ' {
+				continue
+			}
+			seatType, ok := layoutSeatTypes[ch]
+			if !ok {
+				return 0, 0, nil, fmt.Errorf("unknown layout character %q in row %s", ch, rowLetter)
+			}
+			number++
+			seats = append(seats, Seat{
+				SeatCode:  fmt.Sprintf("%s%d", rowLetter, number),
+				SeatClass: seatClassOf(seatType),
+				SeatType:  seatType,
+				Row:       i + 1,
+				Column:    j + 1,
+			})
+		}
+	}
+	if len(seats) == 0 {
+		return 0, 0, nil, fmt.Errorf("layout has no seats")
+	}
+	return len(layout), columns, seats, nil
+}
+
+// HallLayout menyusun kembali layout dari kursi yang tersimpan
+func HallLayout(rows, columns int, seats []Seat) []string {
+	grid := make([][]rune, rows)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(string(LayoutGap), columns))
+	}
+	for _, s := range seats {
+		if s.Row < 1 || s.Row > rows || s.Column < 1 || s.Column > columns {
+			continue
+		}
+		ch := rune(LayoutRegular)
+		for r, t := range layoutSeatTypes {
+			if t == s.SeatType {
+				ch = r
+			}
+		}
+		grid[s.Row-1][s.Column-1] = ch
+	}
+
+	layout := make([]string, rows)
+	for i, row := range grid {
+		layout[i] = string(row)
+	}
+	return layout
+}
+
+// kelas harga mengikuti tipe kursi, selain vip dan couple dihargai regular
+func seatClassOf(seatType string) string {
+	switch seatType {
+	case "vip", "couple":
+		return seatType
+	}
+	return "regular"
+}
+
+type SeatStatus string
+
+const (
+	SeatAvailable SeatStatus = "available"
+	SeatHeld      SeatStatus = "held"
+	SeatSold      SeatStatus = "sold"
+	SeatBlocked   SeatStatus = "blocked"
+)
+
+// SeatMapSeat adalah satu kursi di seat map beserta statusnya untuk schedule tertentu
+type SeatMapSeat struct {
+	ID        int        `json:"id"`
+	SeatCode  string     `json:"seat_code"`
+	Row       int        `json:"row"`
+	Column    int        `json:"column"`
+	SeatType  string     `json:"seat_type"`
+	SeatClass string     `json:"seat_class"`
+	Price     int        `json:"price"`
+	Status    SeatStatus `json:"status"`
+	HeldByYou bool       `json:"held_by_you,omitempty"`
+}
+
+// SeatMapRow berisi satu baris grid, cell null berarti lorong atau celah
+type SeatMapRow struct {
+	Row   int            `json:"row"`
+	Label string         `json:"label"`
+	Cells []*SeatMapSeat `json:"cells"`
+}
+
+type SeatMap struct {
+	ScheduleID int          `json:"schedule_id"`
+	HallID     int          `json:"hall_id"`
+	HallName   string       `json:"hall_name"`
+	Rows       int          `json:"rows"`
+	Columns    int          `json:"columns"`
+	Grid       []SeatMapRow `json:"grid"`
+}
+
+// NewSeatMap menyusun kursi ke dalam grid rows x columns
+func NewSeatMap(scheduleID int, hall Hall, seats []*SeatMapSeat) *SeatMap {
+	m := &SeatMap{
+		ScheduleID: scheduleID,
+		HallID:     hall.ID,
+		HallName:   hall.Name,
+		Rows:       hall.Rows,
+		Columns:    hall.Columns,
+		Grid:       make([]SeatMapRow, hall.Rows),
+	}
+	for i := range m.Grid {
+		m.Grid[i] = SeatMapRow{
+			Row:   i + 1,
+			Label: string(rune('A' + i)),
+			Cells: make([]*SeatMapSeat, hall.Columns),
+		}
+	}
+	for _, s := range seats {
+		if s.Row < 1 || s.Row > hall.Rows || s.Column < 1 || s.Column > hall.Columns {
+			continue
+		}
+		m.Grid[s.Row-1].Cells[s.Column-1] = s
+	}
+	return m
+}
+
+// ApplyHolds menandai kursi yang masih available tapi sedang di-hold
+func (m *SeatMap) ApplyHolds(held map[int]uuid.UUID, userID uuid.UUID) {
+	for _, row := range m.Grid {
+		for _, seat := range row.Cells {
+			if seat == nil || seat.Status != SeatAvailable {
+				continue
+			}
+			if owner, isHeld := held[seat.ID]; isHeld {
+				seat.Status = SeatHeld
+				seat.HeldByYou = userID != uuid.Nil && owner == userID
+			}
+		}
+	}
+}
+
+type SeatEventType string
+
+const (
+	SeatEventHeld     SeatEventType = "held"
+	SeatEventReleased SeatEventType = "released"
+	SeatEventSold     SeatEventType = "sold"
+)
+
+// SeatEvent adalah perubahan status kursi yang dikirim ke seat picker secara live
+type SeatEvent struct {
+	Type       SeatEventType `json:"type"`
+	ScheduleID int           `json:"schedule_id"`
+	SeatIDs    []int         `json:"seat_ids"`
+	Status     SeatStatus    `json:"status"`
+}
+
+// NewSeatEvent mengisi status kursi sesuai jenis event
+func NewSeatEvent(t SeatEventType, scheduleID int, seatIDs []int) SeatEvent {
+	status := SeatAvailable
+	switch t {
+	case SeatEventHeld:
+		status = SeatHeld
+	case SeatEventSold:
+		status = SeatSold
+	}
+	return SeatEvent{Type: t, ScheduleID: scheduleID, SeatIDs: seatIDs, Status: status}
+}
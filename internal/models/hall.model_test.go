@@ -0,0 +1,116 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseHallLayout(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  []string
+		rows    int
+		columns int
+		codes   []string
+		wantErr bool
+	}{
+		{
+			name:    "aisle is not numbered",
+			layout:  []string{"SS_SS"},
+			rows:    1,
+			columns: 5,
+			codes:   []string{"A1", "A2", "A3", "A4"},
+		},
+		{
+			name:    "rows get letters and widest row sets columns",
+			layout:  []string{"VV", "ssc  "},
+			rows:    2,
+			columns: 3,
+			codes:   []string{"A1", "A2", "B1", "B2", "B3"},
+		},
+		{name: "empty layout", layout: nil, wantErr: true},
+		{name: "too many rows", layout: slices.Repeat([]string{"S"}, 27), wantErr: true},
+		{name: "unknown character", layout: []string{"SXS"}, wantErr: true},
+		{name: "no seats", layout: []string{"___"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, columns, seats, err := ParseHallLayout(tt.layout)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rows != tt.rows || columns != tt.columns {
+				t.Fatalf("expected %dx%d grid, got %dx%d", tt.rows, tt.columns, rows, columns)
+			}
+			var codes []string
+			for _, s := range seats {
+				codes = append(codes, s.SeatCode)
+			}
+			if !slices.Equal(codes, tt.codes) {
+				t.Fatalf("expected seat codes %v, got %v", tt.codes, codes)
+			}
+		})
+	}
+}
+
+func TestParseHallLayoutSeatTypes(t *testing.T) {
+	_, _, seats, err := ParseHallLayout([]string{"SV_CW"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Seat{
+		{SeatCode: "A1", SeatType: "regular", SeatClass: "regular", Row: 1, Column: 1},
+		{SeatCode: "A2", SeatType: "vip", SeatClass: "vip", Row: 1, Column: 2},
+		{SeatCode: "A3", SeatType: "couple", SeatClass: "couple", Row: 1, Column: 4},
+		{SeatCode: "A4", SeatType: "wheelchair", SeatClass: "regular", Row: 1, Column: 5},
+	}
+	if !slices.Equal(seats, want) {
+		t.Fatalf("expected seats %+v, got %+v", want, seats)
+	}
+}
+
+func TestNewSeatMap(t *testing.T) {
+	hall := Hall{ID: 3, Name: "Studio 1", Rows: 2, Columns: 3}
+	seats := []*SeatMapSeat{
+		{ID: 1, SeatCode: "A1", Row: 1, Column: 1},
+		{ID: 2, SeatCode: "A2", Row: 1, Column: 3},
+		{ID: 3, SeatCode: "B1", Row: 2, Column: 2},
+		{ID: 4, SeatCode: "Z9", Row: 3, Column: 1},
+		{ID: 5, SeatCode: "A0", Row: 1, Column: 0},
+	}
+
+	m := NewSeatMap(7, hall, seats)
+	if m.ScheduleID != 7 || m.HallID != 3 || m.HallName != "Studio 1" || m.Rows != 2 || m.Columns != 3 {
+		t.Fatalf("unexpected seat map header %+v", m)
+	}
+	if len(m.Grid) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(m.Grid))
+	}
+
+	want := [][]int{{1, 0, 2}, {0, 3, 0}}
+	for i, row := range m.Grid {
+		if row.Row != i+1 || row.Label != string(rune('A'+i)) {
+			t.Fatalf("unexpected row header %d %q", row.Row, row.Label)
+		}
+		if len(row.Cells) != 3 {
+			t.Fatalf("expected 3 cells in row %s, got %d", row.Label, len(row.Cells))
+		}
+		for j, cell := range row.Cells {
+			got := 0
+			if cell != nil {
+				got = cell.ID
+			}
+			if got != want[i][j] {
+				t.Fatalf("row %s column %d: expected seat %d, got %d", row.Label, j+1, want[i][j], got)
+			}
+		}
+	}
+}
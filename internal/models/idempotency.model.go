@@ -0,0 +1,11 @@
+package models
+
+// IdempotencyRecord menyimpan hasil request yang memakai Idempotency-Key.
+// selama request pertama masih diproses Done bernilai false dan response belum ada.
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Done        bool   `json:"done"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
@@ -1,48 +1,124 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type OrderStatus string
+
+const (
+	OrderPending     OrderStatus = "pending"
+	OrderPaid        OrderStatus = "paid"
+	OrderUsed        OrderStatus = "used"
+	OrderExpired     OrderStatus = "expired"
+	OrderCancelled   OrderStatus = "cancelled"
+	OrderRefunded    OrderStatus = "refunded"
+	OrderTransferred OrderStatus = "transferred" // seluruh kursinya sudah dipindah ke order penerima transfer
+)
+
+// transisi status order yang diperbolehkan
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending: {OrderPaid, OrderExpired, OrderCancelled},
+	OrderPaid:    {OrderUsed, OrderCancelled, OrderRefunded, OrderTransferred},
+	// pembayaran tetap tercatat di order asal, jadi penayangan yang batal tetap direfund ke pengirim
+	OrderTransferred: {OrderRefunded},
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return slices.Contains(orderTransitions[s], next)
+}
+
+// SeatConsumingStatuses adalah status order yang masih memakai kursi.
+// order transferred tidak punya kursi lagi tapi tetap dihitung supaya pemakaian promonya tidak hilang.
+var SeatConsumingStatuses = []OrderStatus{OrderPending, OrderPaid, OrderUsed, OrderTransferred}
+
+func (s OrderStatus) ConsumesSeats() bool {
+	return slices.Contains(SeatConsumingStatuses, s)
+}
+
 type Order struct {
-	ID         int        `db:"id" json:"id"`
-	QRCode     string     `db:"qr_code" json:"qr_code"`
-	UserID     uuid.UUID  `db:"users_id" json:"user_id"`
-	ScheduleID int        `db:"schedules_id" json:"schedule_id"`
-	PaymentID  int        `db:"payments_id" json:"payment_id"`
-	FullName   string     `db:"fullname" json:"fullname"`
-	Email      string     `db:"email" json:"email"`
-	Phone      string     `db:"phone_number" json:"phone"`
-	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt  *time.Time `db:"updated_at" json:"updated_at"`
-	Seats      []Seat     `db:"-" json:"seats"`
+	ID                  int         `db:"id" json:"id"`
+	QRCode              string      `db:"qr_code" json:"-"` // token check-in, hanya lewat /orders/:id/qr, ticket.pdf dan email tiket
+	UserID              uuid.UUID   `db:"users_id" json:"user_id"`
+	ScheduleID          int         `db:"schedules_id" json:"schedule_id"`
+	PaymentID           int         `db:"payments_id" json:"payment_id"`
+	FullName            string      `db:"fullname" json:"fullname"`
+	Email               string      `db:"email" json:"email"`
+	Phone               string      `db:"phone_number" json:"phone"`
+	Subtotal            int         `db:"subtotal" json:"subtotal"`
+	Fee                 int         `db:"fee" json:"fee"`
+	PromoID             *int        `db:"promos_id" json:"promo_id,omitempty"`
+	PromoCode           *string     `db:"promo_code" json:"promo_code,omitempty"`
+	PromoDiscount       int         `db:"promo_discount" json:"promo_discount"`
+	PointsRedeemed      int         `db:"points_redeemed" json:"points_redeemed"`
+	Discount            int         `db:"discount" json:"discount"`
+	Total               int         `db:"total" json:"total"`
+	Status              OrderStatus `db:"status" json:"status"`
+	PaymentDeadline     *time.Time  `db:"payment_deadline" json:"payment_deadline"`
+	PaidAt              *time.Time  `db:"paid_at" json:"paid_at"`
+	UsedAt              *time.Time  `db:"used_at" json:"used_at"`
+	ExpiredAt           *time.Time  `db:"expired_at" json:"expired_at"`
+	CancelledAt         *time.Time  `db:"cancelled_at" json:"cancelled_at"`
+	RefundedAt          *time.Time  `db:"refunded_at" json:"refunded_at"`
+	TransferredAt       *time.Time  `db:"transferred_at" json:"transferred_at"`
+	RefundAmount        int         `db:"refund_amount" json:"refund_amount"`
+	RefundReason        *string     `db:"refund_reason" json:"refund_reason,omitempty"`
+	PaymentProvider     *string     `db:"payment_provider" json:"payment_provider,omitempty"`
+	PaymentReference    *string     `db:"payment_reference" json:"payment_reference,omitempty"`
+	PaymentURL          *string     `db:"payment_url" json:"payment_url,omitempty"`
+	PaymentInstructions *string     `db:"payment_instructions" json:"payment_instructions,omitempty"`
+	CreatedAt           time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt           *time.Time  `db:"updated_at" json:"updated_at"`
+	Seats               []Seat      `db:"-" json:"seats"`
 }
 
 type Schedule struct {
-	ID         int       `db:"id" json:"id"`
-	MovieID    int       `db:"movies_id" json:"movie_id"`
-	CinemaID   int       `db:"cinemas_id" json:"cinema_id"`
-	TimeID     int       `db:"times_id" json:"time_id"`
-	LocationID int       `db:"locations_id" json:"location_id"`
-	Date       time.Time `db:"date" json:"date"`
+	ID          int        `db:"id" json:"id"`
+	MovieID     int        `db:"movies_id" json:"movie_id"`
+	CinemaID    int        `db:"cinemas_id" json:"cinema_id"`
+	TimeID      int        `db:"times_id" json:"time_id"`
+	LocationID  int        `db:"locations_id" json:"location_id"`
+	HallID      int        `db:"halls_id" json:"hall_id"`
+	Date        time.Time  `db:"date" json:"date"`
+	StartsAt    time.Time  `db:"starts_at" json:"starts_at" example:"2025-12-01T19:30:00+07:00"`
+	EndsAt      time.Time  `db:"-" json:"ends_at" example:"2025-12-01T21:45:00+07:00"`
+	Price       int        `db:"price" json:"price"`
+	CancelledAt *time.Time `db:"cancelled_at" json:"cancelled_at,omitempty"`
 }
 
 // komposite pk
 type OrderSeat struct {
 	OrderID int `db:"orders_id" json:"order_id"`
 	SeatID  int `db:"seats_id" json:"seat_id"`
+	Price   int `db:"price" json:"price"`
 }
 
 type Seat struct {
-	ID       int    `db:"id" json:"id"`
-	SeatCode string `db:"seat_code" json:"seat_code"`
+	ID        int    `db:"id" json:"id"`
+	SeatCode  string `db:"seat_code" json:"seat_code"`
+	SeatClass string `db:"seat_class" json:"seat_class,omitempty"`
+	SeatType  string `db:"seat_type" json:"seat_type,omitempty"`
+	Row       int    `db:"grid_row" json:"row,omitempty"`
+	Column    int    `db:"grid_col" json:"column,omitempty"`
+	IsBlocked bool   `db:"is_blocked" json:"is_blocked,omitempty"`
+	Price     int    `db:"-" json:"price,omitempty"`
+}
+
+// PriceOverride mengganti harga schedule untuk cinema dan/atau kelas kursi tertentu
+type PriceOverride struct {
+	ID        int     `db:"id" json:"id"`
+	CinemaID  *int    `db:"cinemas_id" json:"cinema_id"`
+	SeatClass *string `db:"seat_class" json:"seat_class"`
+	Price     int     `db:"price" json:"price"`
 }
 
 type PaymentMethod struct {
-	ID   int    `db:"id" json:"id"`
-	Name string `db:"name" json:"name"`
+	ID       int    `db:"id" json:"id"`
+	Name     string `db:"name" json:"name"`
+	Provider string `db:"provider" json:"provider"`
 }
 
 type Cinema struct {
@@ -51,8 +127,9 @@ type Cinema struct {
 }
 
 type Location struct {
-	ID   int    `db:"id" json:"id"`
-	Name string `db:"name" json:"name"`
+	ID       int    `db:"id" json:"id"`
+	Name     string `db:"name" json:"name"`
+	Timezone string `db:"timezone" json:"timezone" example:"Asia/Jakarta"`
 }
 
 type Time struct {
@@ -62,10 +139,39 @@ type Time struct {
 
 type OrderDetail struct {
 	Order
-	Movie       Movie     `json:"movie"`
-	CinemaName  string    `json:"cinema_name"`
-	Location    string    `json:"location"`
-	TimeStr     string    `json:"time"`
-	Date        time.Time `json:"date"`
-	PaymentName string    `json:"payment"`
+	Movie       Movie           `json:"movie"`
+	CinemaName  string          `json:"cinema_name"`
+	Location    string          `json:"location"`
+	TimeStr     string          `json:"time"`
+	Date        time.Time       `json:"date"`
+	StartsAt    time.Time       `json:"starts_at" example:"2025-12-01T19:30:00+07:00"`
+	EndsAt      time.Time       `json:"ends_at" example:"2025-12-01T21:45:00+07:00"`
+	PaymentName string          `json:"payment"`
+	Transfers   []OrderTransfer `json:"transfers,omitempty"`
+}
+
+type CheckInReason string
+
+const (
+	CheckInAccepted     CheckInReason = "accepted"
+	CheckInUnknownToken CheckInReason = "unknown_token"
+	CheckInAlreadyUsed  CheckInReason = "already_used"
+	CheckInNotPaid      CheckInReason = "not_paid"
+	CheckInWrongCinema  CheckInReason = "wrong_cinema"
+	CheckInWrongDay     CheckInReason = "wrong_day"
+	CheckInNoSeats      CheckInReason = "no_seats"
+)
+
+// CheckInResult adalah hasil scan tiket di pintu studio
+type CheckInResult struct {
+	Accepted    bool          `json:"accepted"`
+	Reason      CheckInReason `json:"reason"`
+	OrderID     int           `json:"order_id,omitempty"`
+	MovieTitle  string        `json:"movie_title,omitempty"`
+	CinemaName  string        `json:"cinema_name,omitempty"`
+	Date        *time.Time    `json:"date,omitempty"`
+	TimeStr     string        `json:"time,omitempty"`
+	StartsAt    *time.Time    `json:"starts_at,omitempty"`
+	SeatCodes   []string      `json:"seat_codes,omitempty"`
+	CheckedInAt *time.Time    `json:"checked_in_at,omitempty"`
 }
@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestOrderStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from OrderStatus
+		to   OrderStatus
+		want bool
+	}{
+		{OrderPending, OrderPaid, true},
+		{OrderPending, OrderExpired, true},
+		{OrderPending, OrderCancelled, true},
+		{OrderPending, OrderUsed, false},
+		{OrderPending, OrderRefunded, false},
+		{OrderPending, OrderPending, false},
+		{OrderPaid, OrderUsed, true},
+		{OrderPaid, OrderCancelled, true},
+		{OrderPaid, OrderRefunded, true},
+		{OrderPaid, OrderTransferred, true},
+		{OrderPaid, OrderExpired, false},
+		{OrderPaid, OrderPending, false},
+		{OrderUsed, OrderRefunded, false},
+		{OrderUsed, OrderCancelled, false},
+		{OrderExpired, OrderPaid, false},
+		{OrderCancelled, OrderPaid, false},
+		{OrderRefunded, OrderPaid, false},
+		{OrderTransferred, OrderRefunded, true},
+		{OrderTransferred, OrderPaid, false},
+		{OrderTransferred, OrderUsed, false},
+		{OrderTransferred, OrderCancelled, false},
+		{OrderStatus("unknown"), OrderPaid, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("%s -> %s: expected %v, got %v", tt.from, tt.to, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PointsReason string
+
+const (
+	PointsOpeningBalance PointsReason = "opening_balance"
+	PointsEarned         PointsReason = "order_paid"
+	PointsReversed       PointsReason = "order_reversed"
+	PointsRedeemed       PointsReason = "order_redeemed"
+	PointsRedeemRefunded PointsReason = "redeem_refunded"
+)
+
+// PointsEntry adalah satu baris perubahan poin, Balance adalah saldo setelah perubahan
+type PointsEntry struct {
+	ID        int          `db:"id" json:"id"`
+	UserID    uuid.UUID    `db:"users_id" json:"-"`
+	OrderID   *int         `db:"orders_id" json:"order_id,omitempty"`
+	Delta     int          `db:"delta" json:"delta"`
+	Balance   int          `db:"balance" json:"balance"`
+	Reason    PointsReason `db:"reason" json:"reason"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+}
+
+type PointsHistory struct {
+	Balance int           `json:"balance"`
+	History []PointsEntry `json:"history"`
+}
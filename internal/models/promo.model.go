@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type PromoType string
+
+const (
+	PromoPercent PromoType = "percent"
+	PromoFixed   PromoType = "fixed"
+)
+
+type Promo struct {
+	ID            int        `db:"id" json:"id"`
+	Code          string     `db:"code" json:"code"`
+	Description   *string    `db:"description" json:"description,omitempty"`
+	DiscountType  PromoType  `db:"discount_type" json:"discount_type"`
+	DiscountValue int        `db:"discount_value" json:"discount_value"`
+	MaxDiscount   *int       `db:"max_discount" json:"max_discount,omitempty"`
+	MinTickets    int        `db:"min_tickets" json:"min_tickets"`
+	ValidFrom     *time.Time `db:"valid_from" json:"valid_from,omitempty"`
+	ValidUntil    *time.Time `db:"valid_until" json:"valid_until,omitempty"`
+	UsageLimit    *int       `db:"usage_limit" json:"usage_limit,omitempty"`
+	PerUserLimit  *int       `db:"per_user_limit" json:"per_user_limit,omitempty"`
+	IsActive      bool       `db:"is_active" json:"is_active"`
+	UsedCount     int        `db:"-" json:"used_count"`
+	MovieIDs      []int      `db:"-" json:"movie_ids"`
+	CinemaIDs     []int      `db:"-" json:"cinema_ids"`
+	CreatedAt     time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt     *time.Time `db:"updated_at" json:"updated_at,omitempty"`
+}
+
+// Discount menghitung potongan promo untuk subtotal tiket, tidak pernah melebihi subtotal
+func (p *Promo) Discount(subtotal int) int {
+	discount := p.DiscountValue
+	if p.DiscountType == PromoPercent {
+		discount = subtotal * p.DiscountValue / 100
+		if p.MaxDiscount != nil && discount > *p.MaxDiscount {
+			discount = *p.MaxDiscount
+		}
+	}
+	return min(discount, subtotal)
+}
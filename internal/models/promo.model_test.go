@@ -0,0 +1,32 @@
+package models
+
+import "testing"
+
+func TestPromoDiscount(t *testing.T) {
+	maxDiscount := 20000
+
+	tests := []struct {
+		name     string
+		promo    Promo
+		subtotal int
+		want     int
+	}{
+		{"percent", Promo{DiscountType: PromoPercent, DiscountValue: 10}, 100000, 10000},
+		{"percent rounds down", Promo{DiscountType: PromoPercent, DiscountValue: 15}, 33333, 4999},
+		{"percent capped by max discount", Promo{DiscountType: PromoPercent, DiscountValue: 50, MaxDiscount: &maxDiscount}, 100000, 20000},
+		{"percent below max discount", Promo{DiscountType: PromoPercent, DiscountValue: 10, MaxDiscount: &maxDiscount}, 100000, 10000},
+		{"full percent", Promo{DiscountType: PromoPercent, DiscountValue: 100}, 75000, 75000},
+		{"fixed", Promo{DiscountType: PromoFixed, DiscountValue: 15000}, 100000, 15000},
+		{"fixed ignores max discount", Promo{DiscountType: PromoFixed, DiscountValue: 30000, MaxDiscount: &maxDiscount}, 100000, 30000},
+		{"fixed never exceeds subtotal", Promo{DiscountType: PromoFixed, DiscountValue: 50000}, 40000, 40000},
+		{"zero subtotal", Promo{DiscountType: PromoFixed, DiscountValue: 10000}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.promo.Discount(tt.subtotal); got != tt.want {
+				t.Fatalf("expected discount %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
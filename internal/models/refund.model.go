@@ -0,0 +1,89 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RefundRule berarti pembatalan paling lambat Before sebelum film tayang mendapat Percent persen dana kembali
+type RefundRule struct {
+	Before  time.Duration
+	Percent int
+}
+
+// RefundPolicy diurutkan dari batas waktu paling jauh sebelum tayang
+type RefundPolicy []RefundRule
+
+// ParseRefundPolicy membaca format "24h:100,2h:50", batas yang tidak tercantum berarti tidak ada refund
+func ParseRefundPolicy(s string) (RefundPolicy, error) {
+	var policy RefundPolicy
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		before, percent, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid refund rule %q", part)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(before))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid refund rule %q", part)
+		}
+		p, err := strconv.Atoi(strings.TrimSpace(percent))
+		if err != nil || p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid refund rule %q", part)
+		}
+		policy = append(policy, RefundRule{Before: d, Percent: p})
+	}
+	slices.SortFunc(policy, func(a, b RefundRule) int {
+		return cmp.Compare(b.Before, a.Before)
+	})
+	return policy, nil
+}
+
+// Percent mengembalikan persen refund kalau dibatalkan untilShow sebelum tayang
+func (p RefundPolicy) Percent(untilShow time.Duration) int {
+	for _, rule := range p {
+		if untilShow >= rule.Before {
+			return rule.Percent
+		}
+	}
+	return 0
+}
+
+func (p RefundPolicy) Amount(total int, untilShow time.Duration) int {
+	return total * p.Percent(untilShow) / 100
+}
+
+type RefundStatus string
+
+const (
+	RefundPending   RefundStatus = "pending"
+	RefundSucceeded RefundStatus = "succeeded"
+)
+
+// Refund adalah dana yang harus dikembalikan lewat payment provider.
+// LastError berisi pesan provider dari percobaan terakhir yang gagal.
+type Refund struct {
+	ID               int          `db:"id" json:"id"`
+	OrderID          int          `db:"orders_id" json:"order_id"`
+	Provider         *string      `db:"provider" json:"provider,omitempty"`
+	PaymentReference *string      `db:"payment_reference" json:"payment_reference,omitempty"`
+	Amount           int          `db:"amount" json:"amount"`
+	Reason           string       `db:"reason" json:"reason"`
+	Status           RefundStatus `db:"status" json:"status"`
+	Attempts         int          `db:"attempts" json:"attempts"`
+	LastError        *string      `db:"last_error" json:"last_error,omitempty"`
+	CreatedAt        time.Time    `db:"created_at" json:"created_at"`
+	RefundedAt       *time.Time   `db:"refunded_at" json:"refunded_at,omitempty"`
+}
+
+// Key adalah idempotency key refund di payment provider, tetap sama di setiap percobaan ulang
+func (r *Refund) Key() string {
+	return fmt.Sprintf("refund-%d", r.ID)
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseRefundPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    RefundPolicy
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: nil},
+		{
+			name:  "sorted from the furthest deadline",
+			input: "2h:50, 24h:100",
+			want:  RefundPolicy{{Before: 24 * time.Hour, Percent: 100}, {Before: 2 * time.Hour, Percent: 50}},
+		},
+		{
+			name:  "blank rules are skipped",
+			input: "48h:100,,",
+			want:  RefundPolicy{{Before: 48 * time.Hour, Percent: 100}},
+		},
+		{name: "missing percent", input: "24h", wantErr: true},
+		{name: "invalid duration", input: "1day:100", wantErr: true},
+		{name: "negative duration", input: "-1h:100", wantErr: true},
+		{name: "percent above 100", input: "24h:150", wantErr: true},
+		{name: "negative percent", input: "24h:-10", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRefundPolicy(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected policy %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRefundPolicyAmount(t *testing.T) {
+	policy, err := ParseRefundPolicy("24h:100,2h:50")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		total     int
+		untilShow time.Duration
+		want      int
+	}{
+		{"well before the show", 100000, 72 * time.Hour, 100000},
+		{"exactly at the first deadline", 100000, 24 * time.Hour, 100000},
+		{"between deadlines", 100000, 5 * time.Hour, 50000},
+		{"exactly at the last deadline", 100000, 2 * time.Hour, 50000},
+		{"after the last deadline", 100000, time.Hour, 0},
+		{"show already started", 100000, -time.Minute, 0},
+		{"rounds down", 33333, 5 * time.Hour, 16666},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Amount(tt.total, tt.untilShow); got != tt.want {
+				t.Fatalf("expected refund %d, got %d", tt.want, got)
+			}
+		})
+	}
+
+	if got := RefundPolicy(nil).Amount(100000, 72*time.Hour); got != 0 {
+		t.Fatalf("expected no refund without policy, got %d", got)
+	}
+}
@@ -0,0 +1,227 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScheduleDetail adalah schedule beserta nama movie, cinema, lokasi, studio dan jumlah kursi terjual
+type ScheduleDetail struct {
+	Schedule
+	MovieTitle   string `json:"movie_title"`
+	CinemaName   string `json:"cinema_name"`
+	LocationName string `json:"location_name"`
+	HallName     string `json:"hall_name"`
+	Timezone     string `json:"timezone" example:"Asia/Jakarta"`
+	Time         string `json:"time"`
+	SoldSeats    int    `json:"sold_seats"`
+	TotalSeats   int    `json:"total_seats"`
+}
+
+// ScheduleFilter menyaring daftar schedule admin, nilai kosong berarti tidak disaring
+type ScheduleFilter struct {
+	MovieID    int
+	CinemaID   int
+	LocationID int
+	HallID     int
+	DateFrom   *time.Time
+	DateTo     *time.Time
+	// "active" (belum dibatalkan dan movie belum dihapus), "cancelled" atau kosong untuk semua
+	Status string
+	// hanya schedule yang jam mulainya belum lewat
+	Upcoming bool
+}
+
+// ScheduleConflict adalah schedule lain di studio yang sama yang jam tayangnya bertabrakan
+type ScheduleConflict struct {
+	ScheduleID int       `json:"schedule_id"`
+	MovieID    int       `json:"movie_id"`
+	MovieTitle string    `json:"movie_title"`
+	HallID     int       `json:"hall_id"`
+	Date       time.Time `json:"date"`
+	Time       string    `json:"time"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+}
+
+// Showing adalah rentang waktu tayang sebuah schedule, End sudah termasuk durasi movie
+type Showing struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewShowing menghitung rentang tayang dari jam mulai dan durasi movie dalam menit
+func NewShowing(start time.Time, durationMinutes int) Showing {
+	return Showing{Start: start, End: start.Add(time.Duration(durationMinutes) * time.Minute)}
+}
+
+// zones menyimpan zona waktu yang sudah dibaca supaya tzdata tidak dibuka ulang di setiap baris
+var zones sync.Map
+
+// LoadZone membaca zona waktu IANA lokasi, misalnya "Asia/Jakarta"
+func LoadZone(name string) (*time.Location, error) {
+	if loc, ok := zones.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	zones.Store(name, loc)
+	return loc, nil
+}
+
+// InZone mengubah t ke zona waktu lokasi supaya offset di JSON sesuai jam lokal cinema,
+// zona yang tidak dikenal dibiarkan apa adanya
+func InZone(t time.Time, zone string) time.Time {
+	loc, err := LoadZone(zone)
+	if err != nil {
+		return t
+	}
+	return t.In(loc)
+}
+
+// SetShowtime mengisi jam mulai dan selesai schedule di zona waktu lokasinya
+func (s *Schedule) SetShowtime(startsAt, endsAt time.Time, zone string) {
+	s.StartsAt, s.EndsAt = InZone(startsAt, zone), InZone(endsAt, zone)
+}
+
+// Overlaps mengecek apakah dua penayangan di studio yang sama bertabrakan,
+// buffer adalah jeda bersih-bersih studio setelah setiap penayangan
+func (s Showing) Overlaps(other Showing, buffer time.Duration) bool {
+	if s.Start.Equal(other.Start) {
+		return true
+	}
+	return s.Start.Before(other.End.Add(buffer)) && other.Start.Before(s.End.Add(buffer))
+}
+
+// Showtime adalah satu jam tayang di daftar schedule publik
+type Showtime struct {
+	ScheduleID     int       `json:"schedule_id"`
+	MovieID        int       `json:"movie_id"`
+	MovieTitle     string    `json:"movie_title"`
+	HallID         int       `json:"hall_id"`
+	HallName       string    `json:"hall_name"`
+	TimeID         int       `json:"time_id"`
+	Time           string    `json:"time"`
+	StartsAt       time.Time `json:"starts_at" example:"2025-12-01T19:30:00+07:00"`
+	EndsAt         time.Time `json:"ends_at" example:"2025-12-01T21:45:00+07:00"`
+	Price          int       `json:"price"`
+	TotalSeats     int       `json:"total_seats"`
+	SeatsRemaining int       `json:"seats_remaining"`
+}
+
+type CinemaShowtimes struct {
+	CinemaID     int        `json:"cinema_id"`
+	CinemaName   string     `json:"cinema_name"`
+	LocationID   int        `json:"location_id"`
+	LocationName string     `json:"location_name"`
+	Showtimes    []Showtime `json:"showtimes"`
+}
+
+type ShowtimeDate struct {
+	Date    string            `json:"date" example:"2025-12-01"`
+	Cinemas []CinemaShowtimes `json:"cinemas"`
+}
+
+// GroupShowtimes mengelompokkan schedule per tanggal lalu per cinema.
+// urutan tanggal dan jam tayang mengikuti input, cinema diurutkan berdasarkan nama.
+func GroupShowtimes(schedules []ScheduleDetail) []ShowtimeDate {
+	dates := []ShowtimeDate{}
+	for _, s := range schedules {
+		date := s.Date.Format("2006-01-02")
+		if len(dates) == 0 || dates[len(dates)-1].Date != date {
+			dates = append(dates, ShowtimeDate{Date: date})
+		}
+		day := &dates[len(dates)-1]
+
+		i := slices.IndexFunc(day.Cinemas, func(c CinemaShowtimes) bool {
+			return c.CinemaID == s.CinemaID && c.LocationID == s.LocationID
+		})
+		if i < 0 {
+			day.Cinemas = append(day.Cinemas, CinemaShowtimes{
+				CinemaID:     s.CinemaID,
+				CinemaName:   s.CinemaName,
+				LocationID:   s.LocationID,
+				LocationName: s.LocationName,
+			})
+			i = len(day.Cinemas) - 1
+		}
+		day.Cinemas[i].Showtimes = append(day.Cinemas[i].Showtimes, Showtime{
+			ScheduleID:     s.ID,
+			MovieID:        s.MovieID,
+			MovieTitle:     s.MovieTitle,
+			HallID:         s.HallID,
+			HallName:       s.HallName,
+			TimeID:         s.TimeID,
+			Time:           s.Time,
+			StartsAt:       s.StartsAt,
+			EndsAt:         s.EndsAt,
+			Price:          s.Price,
+			TotalSeats:     s.TotalSeats,
+			SeatsRemaining: max(s.TotalSeats-s.SoldSeats, 0),
+		})
+	}
+
+	for i := range dates {
+		slices.SortStableFunc(dates[i].Cinemas, func(a, b CinemaShowtimes) int {
+			return cmp.Or(cmp.Compare(a.CinemaName, b.CinemaName), cmp.Compare(a.LocationName, b.LocationName))
+		})
+	}
+	return dates
+}
+
+// SchedulePreview adalah schedule hasil aturan berulang beserta jadwal lain yang bentrok dengannya
+type SchedulePreview struct {
+	Schedule
+	Conflicts []ScheduleConflict `json:"conflicts,omitempty"`
+}
+
+// RecurrenceRule menghasilkan tanggal dari From sampai Until (inklusif) yang jatuh di Weekdays,
+// Weekdays kosong berarti setiap hari
+type RecurrenceRule struct {
+	From     time.Time
+	Until    time.Time
+	Weekdays []time.Weekday
+}
+
+func (r RecurrenceRule) Dates() []time.Time {
+	var dates []time.Time
+	for d := r.From; !d.After(r.Until); d = d.AddDate(0, 0, 1) {
+		if len(r.Weekdays) == 0 || slices.Contains(r.Weekdays, d.Weekday()) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+// ParseWeekday membaca nama hari dalam bahasa Inggris, lengkap ("monday") atau singkat ("mon")
+func ParseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", s)
+}
+
+// format jam tayang yang dipakai di tabel times
+var showTimeLayouts = []string{"15:04", "15:04:05", "3:04pm", "3:04PM", "03:04pm", "03:04PM", "3:04 pm", "3:04 PM"}
+
+// ShowTime menggabungkan tanggal schedule dan jam tayang di zona waktu loc
+func ShowTime(date time.Time, clock string, loc *time.Location) (time.Time, error) {
+	clock = strings.TrimSpace(clock)
+	for _, layout := range showTimeLayouts {
+		t, err := time.Parse(layout, clock)
+		if err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid show time %q", clock)
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestShowTime(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := time.Date(2025, time.October, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		clock   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "24 hour clock", clock: "19:30", want: time.Date(2025, time.October, 20, 19, 30, 0, 0, jakarta)},
+		{name: "with seconds", clock: "08:15:45", want: time.Date(2025, time.October, 20, 8, 15, 45, 0, jakarta)},
+		{name: "lowercase pm", clock: "7:30pm", want: time.Date(2025, time.October, 20, 19, 30, 0, 0, jakarta)},
+		{name: "padded uppercase am", clock: "09:00AM", want: time.Date(2025, time.October, 20, 9, 0, 0, 0, jakarta)},
+		{name: "spaced meridiem", clock: " 12:00 PM ", want: time.Date(2025, time.October, 20, 12, 0, 0, 0, jakarta)},
+		{name: "empty", clock: "", wantErr: true},
+		{name: "out of range", clock: "25:00", wantErr: true},
+		{name: "not a time", clock: "evening", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ShowTime(date, tt.clock, jakarta)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) || got.Location() != jakarta {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestShowingOverlaps(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, time.December, 1, hour, minute, 0, 0, time.UTC)
+	}
+	show := NewShowing(at(13, 0), 120)
+
+	tests := []struct {
+		name   string
+		other  Showing
+		buffer time.Duration
+		want   bool
+	}{
+		{"same start", NewShowing(at(13, 0), 90), 0, true},
+		{"starts during the show", NewShowing(at(14, 0), 120), 0, true},
+		{"ends during the show", NewShowing(at(12, 0), 90), 0, true},
+		{"inside the show", NewShowing(at(13, 30), 30), 0, true},
+		{"starts right after", NewShowing(at(15, 0), 120), 0, false},
+		{"ends right before", NewShowing(at(11, 0), 120), 0, false},
+		{"starts within the cleaning buffer", NewShowing(at(15, 10), 120), 15 * time.Minute, true},
+		{"starts after the cleaning buffer", NewShowing(at(15, 15), 120), 15 * time.Minute, false},
+		{"ends within its own cleaning buffer", NewShowing(at(10, 50), 120), 15 * time.Minute, true},
+		{"zero length at the same start", Showing{Start: at(13, 0), End: at(13, 0)}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := show.Overlaps(tt.other, tt.buffer); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			if got := tt.other.Overlaps(show, tt.buffer); got != tt.want {
+				t.Fatalf("expected symmetric result %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleDates(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2025, time.December, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		rule RecurrenceRule
+		want []time.Time
+	}{
+		{
+			name: "every day",
+			rule: RecurrenceRule{From: day(1), Until: day(3)},
+			want: []time.Time{day(1), day(2), day(3)},
+		},
+		{
+			name: "selected weekdays",
+			rule: RecurrenceRule{From: day(1), Until: day(14), Weekdays: []time.Weekday{time.Monday, time.Friday}},
+			want: []time.Time{day(1), day(5), day(8), day(12)},
+		},
+		{
+			name: "single day",
+			rule: RecurrenceRule{From: day(1), Until: day(1)},
+			want: []time.Time{day(1)},
+		},
+		{
+			name: "weekday outside range",
+			rule: RecurrenceRule{From: day(1), Until: day(2), Weekdays: []time.Weekday{time.Sunday}},
+			want: nil,
+		},
+		{
+			name: "until before from",
+			rule: RecurrenceRule{From: day(3), Until: day(1)},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.Dates()
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Fatalf("expected dates %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"
+	TransferAccepted  TransferStatus = "accepted"
+	TransferDeclined  TransferStatus = "declined"
+	TransferCancelled TransferStatus = "cancelled"
+)
+
+// OrderTransfer adalah pemindahan sebagian atau seluruh kursi order ke user lain.
+// setelah diterima kursinya pindah ke order baru milik penerima (NewOrderID).
+type OrderTransfer struct {
+	ID          int            `json:"id"`
+	OrderID     int            `json:"order_id"`
+	NewOrderID  *int           `json:"new_order_id,omitempty"`
+	FromUserID  uuid.UUID      `json:"from_user_id"`
+	FromEmail   string         `json:"from_email"`
+	ToUserID    uuid.UUID      `json:"to_user_id"`
+	ToEmail     string         `json:"to_email"`
+	SeatCodes   []string       `json:"seat_codes"`
+	Status      TransferStatus `json:"status"`
+	CreatedAt   time.Time      `json:"created_at"`
+	RespondedAt *time.Time     `json:"responded_at,omitempty"`
+}
@@ -12,6 +12,7 @@ const (
 	RoleAdmin   Role = "admin"
 	RoleUser    Role = "user"
 	RoleGeneral Role = "general"
+	RoleStaff   Role = "staff"
 )
 
 type User struct {
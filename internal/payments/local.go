@@ -0,0 +1,103 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const LocalSignatureHeader = "X-Signature"
+
+// LocalProvider adalah provider palsu untuk development dan testing.
+// tagihan disimpan di memori dan pembayaran disimulasikan lewat webhook
+// yang ditandatangani dengan secret yang sama.
+type LocalProvider struct {
+	secret []byte
+	mu     sync.Mutex
+	// reference -> status
+	charges map[string]Status
+	// refund key yang sudah diproses
+	refunds map[string]bool
+}
+
+func NewLocalProvider(secret string) *LocalProvider {
+	return &LocalProvider{
+		secret:  []byte(secret),
+		charges: make(map[string]Status),
+		refunds: make(map[string]bool),
+	}
+}
+
+func (p *LocalProvider) Name() string {
+	return "local"
+}
+
+func (p *LocalProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	ref := fmt.Sprintf("local_%s", uuid.NewString())
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	p.mu.Lock()
+	p.charges[ref] = StatusPending
+	p.mu.Unlock()
+
+	return &Charge{
+		Reference: ref,
+		Status:    StatusPending,
+		Instructions: fmt.Sprintf(
+			"Pay %d for order #%d by sending {\"event_id\":\"<unique id>\",\"reference\":\"%s\",\"status\":\"paid\",\"amount\":%d} "+
+				"to POST /payments/webhook/local with the hex HMAC-SHA256 of the body in the %s header",
+			req.Amount, req.OrderID, ref, req.Amount, LocalSignatureHeader,
+		),
+		ExpiresAt: &expiresAt,
+	}, nil
+}
+
+func (p *LocalProvider) GetStatus(ctx context.Context, reference string) (Status, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status, ok := p.charges[reference]
+	if !ok {
+		return "", ErrChargeNotFound
+	}
+	return status, nil
+}
+
+func (p *LocalProvider) Refund(ctx context.Context, reference, refundKey string, amount int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.charges[reference]; !ok {
+		return ErrChargeNotFound
+	}
+	if p.refunds[refundKey] {
+		return nil
+	}
+	p.refunds[refundKey] = true
+	p.charges[reference] = StatusRefunded
+	return nil
+}
+
+func (p *LocalProvider) ParseWebhook(payload []byte, header http.Header) (*WebhookEvent, error) {
+	if !VerifySignature(p.secret, payload, header.Get(LocalSignatureHeader)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if event.EventID == "" || event.Reference == "" {
+		return nil, fmt.Errorf("incomplete webhook payload")
+	}
+
+	// instance lain bisa saja yang membuat tagihannya, jadi tidak wajib ada di memori
+	p.mu.Lock()
+	p.charges[event.Reference] = event.Status
+	p.mu.Unlock()
+
+	return &event, nil
+}
@@ -0,0 +1,104 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrUnknownProvider  = errors.New("unknown payment provider")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrChargeNotFound   = errors.New("charge not found")
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusPaid     Status = "paid"
+	StatusFailed   Status = "failed"
+	StatusExpired  Status = "expired"
+	StatusRefunded Status = "refunded"
+)
+
+type ChargeRequest struct {
+	OrderID  int
+	Amount   int
+	FullName string
+	Email    string
+	Phone    string
+}
+
+// Charge adalah tagihan yang dibuat di provider untuk satu order
+type Charge struct {
+	Reference    string     `json:"reference"`
+	Status       Status     `json:"status"`
+	RedirectURL  string     `json:"redirect_url,omitempty"`
+	Instructions string     `json:"instructions,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+// WebhookEvent adalah notifikasi dari provider yang sudah diverifikasi
+type WebhookEvent struct {
+	EventID   string `json:"event_id"`
+	Reference string `json:"reference"`
+	Status    Status `json:"status"`
+	Amount    int    `json:"amount"`
+}
+
+type Provider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	GetStatus(ctx context.Context, reference string) (Status, error)
+	// Refund mengembalikan dana tagihan. refundKey unik per refund dan dipakai provider
+	// untuk mengabaikan pemanggilan ulang refund yang sama
+	Refund(ctx context.Context, reference, refundKey string, amount int) error
+	// ParseWebhook memverifikasi signature lalu mengurai payload webhook
+	ParseWebhook(payload []byte, header http.Header) (*WebhookEvent, error)
+}
+
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider)}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+// Sign menghasilkan HMAC-SHA256 (hex) dari payload
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature membandingkan signature dengan waktu konstan
+func VerifySignature(secret, payload []byte, signature string) bool {
+	if len(secret) == 0 || signature == "" {
+		return false
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,37 @@
+package payments
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifySignature(t *testing.T) {
+	secret := []byte("webhook-secret")
+	payload := []byte(`{"order_id":1,"status":"paid"}`)
+	signature := Sign(secret, payload)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		payload   []byte
+		signature string
+		want      bool
+	}{
+		{"valid", secret, payload, signature, true},
+		{"uppercase hex", secret, payload, strings.ToUpper(signature), true},
+		{"tampered payload", secret, []byte(`{"order_id":2,"status":"paid"}`), signature, false},
+		{"wrong secret", []byte("other-secret"), payload, signature, false},
+		{"empty secret", nil, payload, Sign(nil, payload), false},
+		{"empty signature", secret, payload, "", false},
+		{"not hex", secret, payload, "zz" + signature[2:], false},
+		{"truncated", secret, payload, signature[:len(signature)-2], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.secret, tt.payload, tt.signature); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
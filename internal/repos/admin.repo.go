@@ -2,14 +2,16 @@ package repos
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/Darari17/be-tickitz/internal/models"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var ErrPriceOverrideExists = errors.New("price override for this cinema and seat class already exists")
+
 type AdminRepo struct {
 	db *pgxpool.Pool
 }
@@ -97,7 +99,7 @@ func (r *AdminRepo) SoftDeleteMovie(ctx context.Context, id int) error {
 	return err
 }
 
-func (r *AdminRepo) CreateMovie(ctx context.Context, movie *models.Movie, genreNames, castNames []string, schedules []map[string]interface{}) (*models.Movie, error) {
+func (r *AdminRepo) CreateMovie(ctx context.Context, movie *models.Movie, genreNames, castNames []string, schedules []models.Schedule) (*models.Movie, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -138,20 +140,10 @@ func (r *AdminRepo) CreateMovie(ctx context.Context, movie *models.Movie, genreN
 		}
 	}
 
-	for _, s := range schedules {
-		date := s["date"].(time.Time)
-		cinemaID := s["cinema_id"].(int)
-		locationID := s["location_id"].(int)
-		timeIDs := s["time_ids"].([]int)
-
-		for _, tid := range timeIDs {
-			_, err := tx.Exec(ctx, `
-				INSERT INTO schedules (movies_id, cinemas_id, locations_id, times_id, date)
-				VALUES ($1,$2,$3,$4,$5)
-			`, movie.ID, cinemaID, locationID, tid, date)
-			if err != nil {
-				return nil, err
-			}
+	for i := range schedules {
+		schedules[i].MovieID = movie.ID
+		if err := insertSchedule(ctx, tx, &schedules[i]); err != nil {
+			return nil, err
 		}
 	}
 
@@ -161,7 +153,8 @@ func (r *AdminRepo) CreateMovie(ctx context.Context, movie *models.Movie, genreN
 	return r.GetMovieByID(ctx, movie.ID)
 }
 
-func (r *AdminRepo) UpdateMovie(ctx context.Context, id int, update map[string]interface{}, genreNames, castNames []string) error {
+// UpdateMovie mengubah data movie, schedules berisi schedule baru yang ditambahkan ke movie
+func (r *AdminRepo) UpdateMovie(ctx context.Context, id int, update map[string]interface{}, genreNames, castNames []string, schedules []models.Schedule) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return err
@@ -220,5 +213,48 @@ func (r *AdminRepo) UpdateMovie(ctx context.Context, id int, update map[string]i
 		}
 	}
 
+	for i := range schedules {
+		schedules[i].MovieID = id
+		if err := insertSchedule(ctx, tx, &schedules[i]); err != nil {
+			return err
+		}
+	}
+
 	return tx.Commit(ctx)
 }
+
+func (r *AdminRepo) GetPriceOverrides(ctx context.Context) ([]models.PriceOverride, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, cinemas_id, seat_class, price FROM price_overrides ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overrides []models.PriceOverride
+	for rows.Next() {
+		var o models.PriceOverride
+		if err := rows.Scan(&o.ID, &o.CinemaID, &o.SeatClass, &o.Price); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, nil
+}
+
+// CreatePriceOverride menyimpan override baru, kombinasi cinema + kelas kursi yang sama hanya boleh ada satu
+func (r *AdminRepo) CreatePriceOverride(ctx context.Context, o *models.PriceOverride) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO price_overrides (cinemas_id, seat_class, price)
+		VALUES ($1,$2,$3)
+		RETURNING id
+	`, o.CinemaID, o.SeatClass, o.Price).Scan(&o.ID)
+	if isUniqueViolation(err) {
+		return ErrPriceOverrideExists
+	}
+	return err
+}
+
+func (r *AdminRepo) DeletePriceOverride(ctx context.Context, id int) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM price_overrides WHERE id=$1`, id)
+	return err
+}
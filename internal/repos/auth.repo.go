@@ -2,12 +2,18 @@ package repos
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/Darari17/be-tickitz/internal/models"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var ErrGuestOrderNotFound = errors.New("guest order not found")
+
 type AuthRepo struct {
 	db *pgxpool.Pool
 }
@@ -55,3 +61,74 @@ func (ar *AuthRepo) CreateUser(ctx context.Context, user *models.User) error {
 
 	return nil
 }
+
+// CreateGuest membuat akun tamu dengan role general untuk checkout tanpa registrasi.
+// email diisi placeholder dan password dikosongkan supaya akun ini tidak bisa dipakai login.
+func (ar *AuthRepo) CreateGuest(ctx context.Context) (*models.User, error) {
+	user := models.User{
+		ID:   uuid.New(),
+		Role: models.RoleGeneral,
+	}
+	user.Email = fmt.Sprintf("guest-%s@guest.tickitz", user.ID)
+
+	query := "insert into users (id, email, password, role, created_at) values ($1, $2, '', $3, now()) returning created_at"
+	if err := ar.db.QueryRow(ctx, query, user.ID, user.Email, user.Role).Scan(&user.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// DeleteStaleGuests menghapus akun tamu yang dibuat sebelum cutoff dan tidak pernah membuat order.
+// akun tamu yang punya order tetap disimpan supaya link order di email masih bisa dibuka dan diklaim.
+func (ar *AuthRepo) DeleteStaleGuests(ctx context.Context, cutoff time.Time) (int64, error) {
+	tag, err := ar.db.Exec(ctx, `
+		DELETE FROM users u
+		WHERE u.role = $1 AND u.password = '' AND u.email LIKE 'guest-%@guest.tickitz'
+		  AND u.created_at < $2
+		  AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.users_id = u.id)
+		  AND NOT EXISTS (SELECT 1 FROM order_transfers ot WHERE ot.to_users_id = u.id OR ot.from_users_id = u.id)
+	`, models.RoleGeneral, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ClaimGuestOrders memindahkan semua order milik akun tamu pemilik orderID ke userID,
+// mengembalikan jumlah order yang dipindahkan
+func (ar *AuthRepo) ClaimGuestOrders(ctx context.Context, userID uuid.UUID, orderID int) (int, error) {
+	tx, err := ar.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var guestID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		SELECT u.id FROM orders o JOIN users u ON u.id = o.users_id
+		WHERE o.id = $1 AND u.role = $2
+		FOR UPDATE OF u
+	`, orderID, models.RoleGeneral).Scan(&guestID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrGuestOrderNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE orders SET users_id = $1, updated_at = NOW() WHERE users_id = $2`, userID, guestID)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE order_transfers SET from_users_id = $1 WHERE from_users_id = $2`, userID, guestID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE order_transfers SET to_users_id = $1 WHERE to_users_id = $2`, userID, guestID); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
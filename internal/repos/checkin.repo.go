@@ -0,0 +1,117 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/pkg"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CheckInRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCheckInRepo(db *pgxpool.Pool) *CheckInRepo {
+	return &CheckInRepo{db: db}
+}
+
+// CheckIn memvalidasi token tiket untuk cinema tempat staff bertugas lalu menandai
+// kursi-kursinya sudah masuk. tiket hanya bisa dipakai sekali.
+func (cr *CheckInRepo) CheckIn(ctx context.Context, token string, cinemaID int, now time.Time) (*models.CheckInResult, error) {
+	orderID, err := pkg.VerifyTicketToken(token)
+	if err != nil {
+		if errors.Is(err, pkg.ErrInvalidTicket) {
+			return &models.CheckInResult{Reason: models.CheckInUnknownToken}, nil
+		}
+		return nil, err
+	}
+
+	tx, err := cr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		storedToken    string
+		status         models.OrderStatus
+		scheduleCinema int
+		date, startsAt time.Time
+		zone           string
+	)
+	result := &models.CheckInResult{OrderID: orderID}
+	err = tx.QueryRow(ctx, `
+		SELECT o.qr_code, o.status, s.cinemas_id, s.date, s.starts_at, l.timezone, m.title, c.name, t.time,
+		       COALESCE(ARRAY(
+		           SELECT se.seat_code FROM order_seats os
+		           JOIN seats se ON se.id = os.seats_id
+		           WHERE os.orders_id = o.id ORDER BY se.id
+		       ), '{}')
+		FROM orders o
+		JOIN schedules s ON s.id = o.schedules_id
+		JOIN movies m ON m.id = s.movies_id
+		JOIN cinemas c ON c.id = s.cinemas_id
+		JOIN locations l ON l.id = s.locations_id
+		JOIN times t ON t.id = s.times_id
+		WHERE o.id = $1
+		FOR UPDATE OF o
+	`, orderID).Scan(&storedToken, &status, &scheduleCinema, &date, &startsAt, &zone,
+		&result.MovieTitle, &result.CinemaName, &result.TimeStr, &result.SeatCodes)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &models.CheckInResult{Reason: models.CheckInUnknownToken}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	startsAt = models.InZone(startsAt, zone)
+	result.Date = &date
+	result.StartsAt = &startsAt
+
+	// token lama yang sudah diganti tidak berlaku lagi
+	if storedToken != token {
+		return &models.CheckInResult{Reason: models.CheckInUnknownToken}, nil
+	}
+
+	switch {
+	// seluruh kursi sudah ditransfer ke order lain
+	case len(result.SeatCodes) == 0:
+		result.Reason = models.CheckInNoSeats
+		return result, nil
+	case status == models.OrderUsed:
+		result.Reason = models.CheckInAlreadyUsed
+		return result, nil
+	case status != models.OrderPaid:
+		result.Reason = models.CheckInNotPaid
+		return result, nil
+	case scheduleCinema != cinemaID:
+		result.Reason = models.CheckInWrongCinema
+		return result, nil
+	// hari tayang dibandingkan di zona waktu cinema, bukan zona waktu server
+	case startsAt.Format(time.DateOnly) != now.In(startsAt.Location()).Format(time.DateOnly):
+		result.Reason = models.CheckInWrongDay
+		return result, nil
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE order_seats SET checked_in_at = $1
+		WHERE orders_id = $2 AND checked_in_at IS NULL
+	`, now, orderID); err != nil {
+		return nil, err
+	}
+	if _, err := transitionOrder(ctx, tx, orderID, models.OrderUsed); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	result.Accepted = true
+	result.Reason = models.CheckInAccepted
+	result.CheckedInAt = &now
+	return result, nil
+}
@@ -0,0 +1,264 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrHallNotFound   = errors.New("hall not found")
+	ErrHallExists     = errors.New("hall name already used in this cinema")
+	ErrHallInUse      = errors.New("hall already has schedules or sold seats")
+	ErrCinemaNotFound = errors.New("cinema not found")
+)
+
+// UnknownSeatsError dikembalikan ketika kode kursi tidak ada di studio
+type UnknownSeatsError struct {
+	SeatCodes []string
+}
+
+func (e *UnknownSeatsError) Error() string {
+	return fmt.Sprintf("unknown seats: %s", strings.Join(e.SeatCodes, ", "))
+}
+
+type HallRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewHallRepo(db *pgxpool.Pool) *HallRepo {
+	return &HallRepo{db: db}
+}
+
+func (hr *HallRepo) GetHalls(ctx context.Context, cinemaID int) ([]models.Hall, error) {
+	rows, err := hr.db.Query(ctx, `
+		SELECT id, cinemas_id, name, row_count, column_count, created_at, updated_at
+		FROM halls
+		WHERE $1 = 0 OR cinemas_id = $1
+		ORDER BY cinemas_id, id
+	`, cinemaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	halls := []models.Hall{}
+	for rows.Next() {
+		var h models.Hall
+		if err := rows.Scan(&h.ID, &h.CinemaID, &h.Name, &h.Rows, &h.Columns, &h.CreatedAt, &h.UpdatedAt); err != nil {
+			return nil, err
+		}
+		halls = append(halls, h)
+	}
+	return halls, rows.Err()
+}
+
+// GetHall mengembalikan studio beserta kursi dan layout-nya
+func (hr *HallRepo) GetHall(ctx context.Context, id int) (*models.Hall, error) {
+	var h models.Hall
+	err := hr.db.QueryRow(ctx, `
+		SELECT id, cinemas_id, name, row_count, column_count, created_at, updated_at
+		FROM halls WHERE id = $1
+	`, id).Scan(&h.ID, &h.CinemaID, &h.Name, &h.Rows, &h.Columns, &h.CreatedAt, &h.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrHallNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := hr.db.Query(ctx, `
+		SELECT id, seat_code, seat_class, seat_type, grid_row, grid_col, is_blocked
+		FROM seats WHERE halls_id = $1
+		ORDER BY grid_row, grid_col
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s models.Seat
+		if err := rows.Scan(&s.ID, &s.SeatCode, &s.SeatClass, &s.SeatType, &s.Row, &s.Column, &s.IsBlocked); err != nil {
+			return nil, err
+		}
+		h.Seats = append(h.Seats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	h.Layout = models.HallLayout(h.Rows, h.Columns, h.Seats)
+	return &h, nil
+}
+
+// CreateHall menyimpan studio baru beserta kursi hasil ParseHallLayout
+func (hr *HallRepo) CreateHall(ctx context.Context, h *models.Hall) error {
+	tx, err := hr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO halls (cinemas_id, name, row_count, column_count, created_at)
+		VALUES ($1,$2,$3,$4,NOW())
+		RETURNING id, created_at
+	`, h.CinemaID, h.Name, h.Rows, h.Columns).Scan(&h.ID, &h.CreatedAt)
+	if err != nil {
+		return hallError(err)
+	}
+
+	if err := insertHallSeats(ctx, tx, h); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UpdateHall mengganti nama dan/atau layout studio.
+// layout hanya bisa diganti selama belum ada kursi di studio ini yang pernah dipesan.
+func (hr *HallRepo) UpdateHall(ctx context.Context, h *models.Hall, replaceLayout bool) error {
+	tx, err := hr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE halls SET name = $1, row_count = $2, column_count = $3, updated_at = NOW()
+		WHERE id = $4
+	`, h.Name, h.Rows, h.Columns, h.ID)
+	if err != nil {
+		return hallError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrHallNotFound
+	}
+
+	if replaceLayout {
+		var sold bool
+		err := tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM order_seats os JOIN seats se ON se.id = os.seats_id WHERE se.halls_id = $1
+			)
+		`, h.ID).Scan(&sold)
+		if err != nil {
+			return err
+		}
+		if sold {
+			return ErrHallInUse
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM seats WHERE halls_id = $1`, h.ID); err != nil {
+			return err
+		}
+		if err := insertHallSeats(ctx, tx, h); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// SetBlockedSeats mengganti daftar kursi yang diblokir di studio, kursi lain dibuka kembali.
+// kursi yang sudah terjual tetap berlaku untuk order-nya.
+func (hr *HallRepo) SetBlockedSeats(ctx context.Context, id int, seatCodes []string) error {
+	tx, err := hr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE seats SET is_blocked = (seat_code = ANY($2))
+		WHERE halls_id = $1
+		RETURNING seat_code
+	`, id, seatCodes)
+	if err != nil {
+		return err
+	}
+	var codes []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			rows.Close()
+			return err
+		}
+		codes = append(codes, code)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(codes) == 0 {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM halls WHERE id = $1)`, id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrHallNotFound
+		}
+	}
+
+	var unknown []string
+	for _, code := range seatCodes {
+		if !slices.Contains(codes, code) && !slices.Contains(unknown, code) {
+			unknown = append(unknown, code)
+		}
+	}
+	if len(unknown) > 0 {
+		return &UnknownSeatsError{SeatCodes: unknown}
+	}
+	return tx.Commit(ctx)
+}
+
+func (hr *HallRepo) DeleteHall(ctx context.Context, id int) error {
+	tag, err := hr.db.Exec(ctx, `DELETE FROM halls WHERE id = $1`, id)
+	if err != nil {
+		return hallError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrHallNotFound
+	}
+	return nil
+}
+
+func insertHallSeats(ctx context.Context, tx pgx.Tx, h *models.Hall) error {
+	for i := range h.Seats {
+		s := &h.Seats[i]
+		err := tx.QueryRow(ctx, `
+			INSERT INTO seats (seat_code, seat_class, seat_type, halls_id, grid_row, grid_col, is_blocked)
+			VALUES ($1,$2,$3,$4,$5,$6,$7)
+			RETURNING id
+		`, s.SeatCode, s.SeatClass, s.SeatType, h.ID, s.Row, s.Column, s.IsBlocked).Scan(&s.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hallError menerjemahkan pelanggaran constraint tabel halls
+func hallError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch {
+	case pgErr.Code == "23505":
+		return ErrHallExists
+	case pgErr.Code == "23503" && pgErr.ConstraintName == "halls_cinemas_id_fkey":
+		return ErrCinemaNotFound
+	case pgErr.Code == "23503":
+		// masih dipakai schedules
+		return ErrHallInUse
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrIdempotencyMismatch   = errors.New("idempotency key was used with a different request")
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is still being processed")
+)
+
+// kunci request yang sedang diproses dilepas sendiri kalau server mati di tengah jalan
+const idempotencyLockTTL = time.Minute
+
+type IdempotencyRepo struct {
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+func NewIdempotencyRepo(redis *redis.Client) *IdempotencyRepo {
+	ttl := 24 * time.Hour
+	if d, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL")); err == nil && d > 0 {
+		ttl = d
+	}
+	return &IdempotencyRepo{redis: redis, ttl: ttl}
+}
+
+func idempotencyKey(scope, key string) string {
+	return fmt.Sprintf("idempotency:%s:%s", scope, key)
+}
+
+// Begin mengklaim key untuk request baru. kalau key sudah selesai diproses dengan payload yang sama,
+// response yang tersimpan dikembalikan untuk diulang.
+func (ir *IdempotencyRepo) Begin(ctx context.Context, scope, key, fingerprint string) (*models.IdempotencyRecord, error) {
+	data, err := json.Marshal(models.IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+
+	redisKey := idempotencyKey(scope, key)
+	claimed, err := ir.redis.SetNX(ctx, redisKey, data, idempotencyLockTTL).Result()
+	if err != nil {
+		return nil, err
+	}
+	if claimed {
+		return nil, nil
+	}
+
+	stored, err := ir.redis.Get(ctx, redisKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// baru saja expired atau dilepas, anggap request yang sedang berjalan
+		return nil, ErrIdempotencyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record models.IdempotencyRecord
+	if err := json.Unmarshal(stored, &record); err != nil {
+		return nil, err
+	}
+	switch {
+	case record.Fingerprint != fingerprint:
+		return nil, ErrIdempotencyMismatch
+	case !record.Done:
+		return nil, ErrIdempotencyInProgress
+	}
+	return &record, nil
+}
+
+// Complete menyimpan response request pertama selama IDEMPOTENCY_TTL
+func (ir *IdempotencyRepo) Complete(ctx context.Context, scope, key string, record *models.IdempotencyRecord) error {
+	record.Done = true
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return ir.redis.Set(ctx, idempotencyKey(scope, key), data, ir.ttl).Err()
+}
+
+// Release melepas key supaya request bisa diulang, dipakai ketika request gagal di sisi server
+func (ir *IdempotencyRepo) Release(ctx context.Context, scope, key string) error {
+	return ir.redis.Del(ctx, idempotencyKey(scope, key)).Err()
+}
@@ -3,15 +3,83 @@ package repos
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/pkg"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// SeatConflictError dikembalikan ketika kursi sudah terjual untuk schedule yang sama
+type SeatConflictError struct {
+	SeatCodes []string
+}
+
+func (e *SeatConflictError) Error() string {
+	return fmt.Sprintf("seats already booked: %s", strings.Join(e.SeatCodes, ", "))
+}
+
+// Is membuat errors.Is(err, ErrSeatTaken) cocok dengan SeatConflictError
+func (e *SeatConflictError) Is(target error) bool {
+	return target == ErrSeatTaken
+}
+
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrScheduleStarted  = errors.New("schedule has already started")
+	ErrSeatTaken        = errors.New("seats already booked")
+	ErrOrderNotFound    = errors.New("order not found")
+)
+
+// InvalidTransitionError dikembalikan ketika perubahan status order tidak diperbolehkan
+type InvalidTransitionError struct {
+	From models.OrderStatus
+	To   models.OrderStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot change order status from %s to %s", e.From, e.To)
+}
+
+// kolom waktu yang diisi ketika order masuk ke status tertentu
+var orderStatusColumns = map[models.OrderStatus]string{
+	models.OrderPaid:        "paid_at",
+	models.OrderUsed:        "used_at",
+	models.OrderExpired:     "expired_at",
+	models.OrderCancelled:   "cancelled_at",
+	models.OrderRefunded:    "refunded_at",
+	models.OrderTransferred: "transferred_at",
+}
+
+func seatConsumingStatuses() []string {
+	statuses := make([]string, 0, len(models.SeatConsumingStatuses))
+	for _, s := range models.SeatConsumingStatuses {
+		statuses = append(statuses, string(s))
+	}
+	return statuses
+}
+
+// querier dipenuhi oleh *pgxpool.Pool maupun pgx.Tx
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// forUpdate menambahkan FOR UPDATE ke query kalau baris yang dibaca perlu dikunci
+func forUpdate(lock bool) string {
+	if lock {
+		return " FOR UPDATE"
+	}
+	return ""
+}
+
 type OrderRepo struct {
 	db *pgxpool.Pool
 }
@@ -27,52 +95,324 @@ func (or *OrderRepo) CreateOrder(ctx context.Context, order *models.Order, seatI
 	}
 	defer tx.Rollback(ctx)
 
-	if order.QRCode == "" {
-		order.QRCode = fmt.Sprintf("QR-%d", time.Now().Unix())
+	// kunci per schedule supaya pengecekan dan insert kursi tidak balapan
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('schedule_seats'), $1)`, order.ScheduleID); err != nil {
+		return nil, err
 	}
 
+	if err := priceOrder(ctx, tx, order, seatIDs, true); err != nil {
+		return nil, err
+	}
+
+	// token tiket butuh id order, jadi diisi placeholder unik dulu
+	order.QRCode = "PENDING-" + uuid.NewString()
+
+	order.Status = models.OrderPending
+	deadline := time.Now().Add(paymentWindow())
+	order.PaymentDeadline = &deadline
 	query := `
-        INSERT INTO orders (qr_code, users_id, schedules_id, payments_id, fullname, email, phone_number, created_at)
-        VALUES ($1,$2,$3,$4,$5,$6,$7,NOW())
+        INSERT INTO orders (qr_code, users_id, schedules_id, payments_id, fullname, email, phone_number,
+                            subtotal, fee, promos_id, promo_code, promo_discount, points_redeemed, discount,
+                            total, status, payment_deadline, created_at)
+        VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,NOW())
         RETURNING id, created_at
     `
 	err = tx.QueryRow(ctx, query,
 		order.QRCode, order.UserID, order.ScheduleID, order.PaymentID,
 		order.FullName, order.Email, order.Phone,
+		order.Subtotal, order.Fee, order.PromoID, order.PromoCode, order.PromoDiscount,
+		order.PointsRedeemed, order.Discount, order.Total, order.Status, order.PaymentDeadline,
 	).Scan(&order.ID, &order.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, seatID := range seatIDs {
-		_, err := tx.Exec(ctx, `INSERT INTO order_seats (orders_id, seats_id) VALUES ($1,$2)`, order.ID, seatID)
+	if order.PointsRedeemed > 0 {
+		if err := addPoints(ctx, tx, order.UserID, &order.ID, -order.PointsRedeemed, models.PointsRedeemed); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, seat := range order.Seats {
+		_, err := tx.Exec(ctx, `INSERT INTO order_seats (orders_id, seats_id, price) VALUES ($1,$2,$3)`, order.ID, seat.ID, seat.Price)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	rows, err := tx.Query(ctx, `SELECT id, seat_code FROM seats WHERE id = ANY($1)`, seatIDs)
+	order.QRCode, err = issueTicketToken(ctx, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// QuoteOrder menghitung harga order termasuk promo dan poin tanpa menyimpannya.
+// quote hanya membaca lewat transaksi read-only tanpa mengunci promo maupun saldo poin,
+// jadi hasilnya bisa berbeda dengan CreateOrder kalau kuota atau saldo berubah di antaranya.
+func (or *OrderRepo) QuoteOrder(ctx context.Context, order *models.Order, seatIDs []int) (*models.Order, error) {
+	tx, err := or.db.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := priceOrder(ctx, tx, order, seatIDs, false); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// priceOrder mengecek kursi lalu mengisi harga, potongan promo dan potongan poin order.
+// promo dipotong lebih dulu, poin hanya bisa menutup sisa harga tiket.
+// lock mengunci baris promo dan saldo poin sampai transaksi selesai, hanya dipakai saat checkout.
+func priceOrder(ctx context.Context, q querier, order *models.Order, seatIDs []int, lock bool) error {
+	if err := scheduleBookable(ctx, q, order.ScheduleID); err != nil {
+		return err
+	}
+
+	conflicts, err := bookedSeatCodes(ctx, q, order.ScheduleID, seatIDs)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &SeatConflictError{SeatCodes: conflicts}
+	}
+
+	seats, err := priceSeats(ctx, q, order.ScheduleID, seatIDs)
+	if err != nil {
+		return err
+	}
+	if len(seats) != len(seatIDs) {
+		return ErrScheduleNotFound
+	}
+	order.Seats = seats
+	order.Subtotal, order.Fee, order.Total = orderAmounts(seats)
+
+	if order.PromoCode != nil {
+		if err := applyPromo(ctx, q, order, time.Now(), lock); err != nil {
+			return err
+		}
+		order.Total -= order.PromoDiscount
+	}
+
+	if order.PointsRedeemed > 0 {
+		order.Discount, err = redeemDiscount(ctx, q, order.UserID, order.PointsRedeemed, order.Subtotal-order.PromoDiscount, lock)
+		if err != nil {
+			return err
+		}
+		order.Total -= order.Discount
+	}
+	return nil
+}
+
+// seatPriceSQL menghitung harga kursi se untuk schedule s.
+// harga diambil dari price_overrides yang paling spesifik
+// (cinema + kelas kursi, kelas kursi saja, cinema saja), kalau tidak ada pakai harga schedule.
+const seatPriceSQL = `
+		       COALESCE((
+		           SELECT po.price
+		           FROM price_overrides po
+		           WHERE (po.cinemas_id = s.cinemas_id OR po.cinemas_id IS NULL)
+		             AND (po.seat_class = se.seat_class OR po.seat_class IS NULL)
+		           ORDER BY (po.seat_class IS NOT NULL) DESC, (po.cinemas_id IS NOT NULL) DESC
+		           LIMIT 1
+		       ), s.price)`
+
+// priceSeats mengambil kursi beserta harganya untuk schedule tertentu,
+// kursi yang diblokir, schedule yang dibatalkan dan schedule yang sudah mulai tidak ikut
+func priceSeats(ctx context.Context, q querier, scheduleID int, seatIDs []int) ([]models.Seat, error) {
+	rows, err := q.Query(ctx, `
+		SELECT se.id, se.seat_code, se.seat_class,`+seatPriceSQL+` AS price
+		FROM schedules s
+		JOIN seats se ON se.id = ANY($2) AND se.halls_id = s.halls_id AND NOT se.is_blocked
+		WHERE s.id = $1 AND s.cancelled_at IS NULL AND s.starts_at > NOW()
+		ORDER BY se.id
+	`, scheduleID, seatIDs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var seats []models.Seat
 	for rows.Next() {
 		var seat models.Seat
-		if err := rows.Scan(&seat.ID, &seat.SeatCode); err != nil {
+		if err := rows.Scan(&seat.ID, &seat.SeatCode, &seat.SeatClass, &seat.Price); err != nil {
 			return nil, err
 		}
-		order.Seats = append(order.Seats, seat)
+		seats = append(seats, seat)
+	}
+	return seats, rows.Err()
+}
+
+// paymentWindow adalah batas waktu pembayaran order pending (ORDER_PAYMENT_WINDOW), default 15 menit
+func paymentWindow() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("ORDER_PAYMENT_WINDOW")); err == nil && d > 0 {
+		return d
+	}
+	return 15 * time.Minute
+}
+
+// ExpireOverdueOrders meng-expire order pending yang lewat batas bayar, paling banyak limit order per panggilan.
+// hanya satu instance yang memproses dalam satu waktu, instance lain langsung mendapat hasil kosong.
+func (or *OrderRepo) ExpireOverdueOrders(ctx context.Context, now time.Time, limit int) ([]*models.Order, error) {
+	tx, err := or.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock(hashtext('order_expiry'))`).Scan(&locked); err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, nil
+	}
+
+	// order yang sedang dibayar / dibatalkan di transaksi lain dilewati dulu
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM orders
+		WHERE status = $1 AND payment_deadline < $2
+		ORDER BY payment_deadline
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	`, models.OrderPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var expired []*models.Order
+	for _, id := range ids {
+		order, err := transitionOrder(ctx, tx, id, models.OrderExpired)
+		if err != nil {
+			return nil, err
+		}
+		expired = append(expired, order)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
-	return order, nil
+	return expired, nil
+}
+
+// orderAmounts menghitung subtotal, biaya layanan per tiket (ORDER_SERVICE_FEE) dan total
+func orderAmounts(seats []models.Seat) (subtotal, fee, total int) {
+	for _, seat := range seats {
+		subtotal += seat.Price
+	}
+	perTicket, _ := strconv.Atoi(os.Getenv("ORDER_SERVICE_FEE"))
+	fee = perTicket * len(seats)
+	return subtotal, fee, subtotal + fee
+}
+
+// issueTicketToken membuat token tiket baru untuk order, token lama otomatis tidak berlaku
+func issueTicketToken(ctx context.Context, tx pgx.Tx, orderID int) (string, error) {
+	token, err := pkg.GenTicketToken(orderID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE orders SET qr_code = $1 WHERE id = $2`, token, orderID); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ReissueTicketToken mengganti token tiket order
+func (or *OrderRepo) ReissueTicketToken(ctx context.Context, orderID int) (string, error) {
+	tx, err := or.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	token, err := issueTicketToken(ctx, tx, orderID)
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func bookedSeatCodes(ctx context.Context, q querier, scheduleID int, seatIDs []int) ([]string, error) {
+	rows, err := q.Query(ctx, `
+		SELECT se.seat_code
+		FROM orders o
+		JOIN order_seats os ON o.id = os.orders_id
+		JOIN seats se ON se.id = os.seats_id
+		WHERE o.schedules_id = $1 AND os.seats_id = ANY($2) AND o.status = ANY($3)
+		ORDER BY se.id
+	`, scheduleID, seatIDs, seatConsumingStatuses())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, rows.Err()
+}
+
+// CheckScheduleBookable memastikan schedule masih bisa di-hold dan dipesan:
+// ada, belum dibatalkan dan jam tayangnya belum mulai
+func (or *OrderRepo) CheckScheduleBookable(ctx context.Context, scheduleID int) error {
+	return scheduleBookable(ctx, or.db, scheduleID)
 }
 
-func (or *OrderRepo) GetSeatIDsByCodes(ctx context.Context, seatCodes []string) ([]int, error) {
-	rows, err := or.db.Query(ctx, `SELECT id FROM seats WHERE seat_code = ANY($1)`, seatCodes)
+func scheduleBookable(ctx context.Context, q querier, scheduleID int) error {
+	var cancelled, started bool
+	err := q.QueryRow(ctx, `
+		SELECT cancelled_at IS NOT NULL, starts_at <= NOW() FROM schedules WHERE id = $1
+	`, scheduleID).Scan(&cancelled, &started)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrScheduleNotFound
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case cancelled:
+		return ErrScheduleCancelled
+	case started:
+		return ErrScheduleStarted
+	}
+	return nil
+}
+
+// GetSeatIDsByCodes mencari kursi di studio tempat schedule diputar, kursi yang diblokir dianggap tidak ada
+func (or *OrderRepo) GetSeatIDsByCodes(ctx context.Context, scheduleID int, seatCodes []string) ([]int, error) {
+	rows, err := or.db.Query(ctx, `
+		SELECT se.id
+		FROM schedules s
+		JOIN seats se ON se.halls_id = s.halls_id AND NOT se.is_blocked
+		WHERE s.id = $1 AND s.cancelled_at IS NULL AND s.starts_at > NOW() AND se.seat_code = ANY($2)
+	`, scheduleID, seatCodes)
 	if err != nil {
 		return nil, err
 	}
@@ -89,10 +429,39 @@ func (or *OrderRepo) GetSeatIDsByCodes(ctx context.Context, seatCodes []string)
 	return ids, nil
 }
 
+func (or *OrderRepo) GetSeatsByCodes(ctx context.Context, scheduleID int, seatCodes []string) ([]models.Seat, error) {
+	rows, err := or.db.Query(ctx, `
+		SELECT se.id, se.seat_code
+		FROM schedules s
+		JOIN seats se ON se.halls_id = s.halls_id AND NOT se.is_blocked
+		WHERE s.id = $1 AND s.cancelled_at IS NULL AND s.starts_at > NOW() AND se.seat_code = ANY($2)
+		ORDER BY se.id
+	`, scheduleID, seatCodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var seats []models.Seat
+	for rows.Next() {
+		var seat models.Seat
+		if err := rows.Scan(&seat.ID, &seat.SeatCode); err != nil {
+			return nil, err
+		}
+		seats = append(seats, seat)
+	}
+	return seats, nil
+}
+
 func (or *OrderRepo) GetSchedules(ctx context.Context, movieID int) ([]models.Schedule, error) {
 	rows, err := or.db.Query(ctx, `
-		SELECT id, movies_id, cinemas_id, times_id, locations_id, date
-		FROM schedules WHERE movies_id=$1
+		SELECT s.id, s.movies_id, s.cinemas_id, s.times_id, s.locations_id, s.halls_id, s.date, s.price,
+		       s.starts_at, s.starts_at + make_interval(mins => m.duration), l.timezone
+		FROM schedules s
+		JOIN movies m ON m.id = s.movies_id
+		JOIN locations l ON l.id = s.locations_id
+		WHERE s.movies_id=$1 AND s.cancelled_at IS NULL
+		ORDER BY s.starts_at, s.id
 	`, movieID)
 	if err != nil {
 		return nil, err
@@ -101,10 +470,15 @@ func (or *OrderRepo) GetSchedules(ctx context.Context, movieID int) ([]models.Sc
 
 	var schedules []models.Schedule
 	for rows.Next() {
-		var s models.Schedule
-		if err := rows.Scan(&s.ID, &s.MovieID, &s.CinemaID, &s.TimeID, &s.LocationID, &s.Date); err != nil {
+		var (
+			s                models.Schedule
+			startsAt, endsAt time.Time
+			zone             string
+		)
+		if err := rows.Scan(&s.ID, &s.MovieID, &s.CinemaID, &s.TimeID, &s.LocationID, &s.HallID, &s.Date, &s.Price, &startsAt, &endsAt, &zone); err != nil {
 			return nil, err
 		}
+		s.SetShowtime(startsAt, endsAt, zone)
 		schedules = append(schedules, s)
 	}
 	return schedules, nil
@@ -112,16 +486,17 @@ func (or *OrderRepo) GetSchedules(ctx context.Context, movieID int) ([]models.Sc
 
 func (or *OrderRepo) GetAvailableSeats(ctx context.Context, scheduleID int) ([]models.Seat, error) {
 	rows, err := or.db.Query(ctx, `
-		SELECT s.id, s.seat_code
-		FROM seats s
-		WHERE s.id NOT IN (
+		SELECT se.id, se.seat_code, se.seat_class, se.seat_type, se.grid_row, se.grid_col
+		FROM schedules s
+		JOIN seats se ON se.halls_id = s.halls_id AND NOT se.is_blocked
+		WHERE s.id = $1 AND s.cancelled_at IS NULL AND s.starts_at > NOW() AND se.id NOT IN (
 			SELECT os.seats_id
 			FROM orders o
 			JOIN order_seats os ON o.id = os.orders_id
-			WHERE o.schedules_id = $1
+			WHERE o.schedules_id = $1 AND o.status = ANY($2)
 		)
-		ORDER BY s.id
-	`, scheduleID)
+		ORDER BY se.grid_row, se.grid_col
+	`, scheduleID, seatConsumingStatuses())
 	if err != nil {
 		return nil, err
 	}
@@ -130,7 +505,7 @@ func (or *OrderRepo) GetAvailableSeats(ctx context.Context, scheduleID int) ([]m
 	var seats []models.Seat
 	for rows.Next() {
 		var seat models.Seat
-		if err := rows.Scan(&seat.ID, &seat.SeatCode); err != nil {
+		if err := rows.Scan(&seat.ID, &seat.SeatCode, &seat.SeatClass, &seat.SeatType, &seat.Row, &seat.Column); err != nil {
 			return nil, err
 		}
 		seats = append(seats, seat)
@@ -138,15 +513,193 @@ func (or *OrderRepo) GetAvailableSeats(ctx context.Context, scheduleID int) ([]m
 	return seats, nil
 }
 
-func (or *OrderRepo) GetTransactionDetail(ctx context.Context, orderID int) (*models.OrderDetail, error) {
-	sql := `
+// GetOrderSeatIDs mengembalikan id kursi yang dipesan dalam order
+func (or *OrderRepo) GetOrderSeatIDs(ctx context.Context, orderID int) ([]int, error) {
+	rows, err := or.db.Query(ctx, `SELECT seats_id FROM order_seats WHERE orders_id = $1 ORDER BY seats_id`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetSeatMap mengembalikan seluruh kursi studio schedule beserta harga dan status terjual / diblokir.
+// status hold ada di redis sehingga diisi oleh handler.
+func (or *OrderRepo) GetSeatMap(ctx context.Context, scheduleID int) (*models.SeatMap, error) {
+	var hall models.Hall
+	err := or.db.QueryRow(ctx, `
+		SELECT h.id, h.name, h.row_count, h.column_count
+		FROM schedules s
+		JOIN halls h ON h.id = s.halls_id
+		WHERE s.id = $1
+	`, scheduleID).Scan(&hall.ID, &hall.Name, &hall.Rows, &hall.Columns)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := or.db.Query(ctx, `
+		SELECT se.id, se.seat_code, se.grid_row, se.grid_col, se.seat_type, se.seat_class,`+seatPriceSQL+` AS price,
+		       se.is_blocked,
+		       EXISTS (
+		           SELECT 1
+		           FROM orders o
+		           JOIN order_seats os ON o.id = os.orders_id
+		           WHERE o.schedules_id = s.id AND os.seats_id = se.id AND o.status = ANY($2)
+		       ) AS sold
+		FROM schedules s
+		JOIN seats se ON se.halls_id = s.halls_id
+		WHERE s.id = $1
+		ORDER BY se.grid_row, se.grid_col
+	`, scheduleID, seatConsumingStatuses())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var seats []*models.SeatMapSeat
+	for rows.Next() {
+		var (
+			seat          models.SeatMapSeat
+			blocked, sold bool
+		)
+		if err := rows.Scan(&seat.ID, &seat.SeatCode, &seat.Row, &seat.Column, &seat.SeatType, &seat.SeatClass, &seat.Price, &blocked, &sold); err != nil {
+			return nil, err
+		}
+		switch {
+		case sold:
+			seat.Status = models.SeatSold
+		case blocked:
+			seat.Status = models.SeatBlocked
+		default:
+			seat.Status = models.SeatAvailable
+		}
+		seats = append(seats, &seat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return models.NewSeatMap(scheduleID, hall, seats), nil
+}
+
+const orderColumns = `
+		id, qr_code, users_id, schedules_id, payments_id, fullname, email, phone_number,
+		subtotal, fee, promos_id, promo_code, promo_discount, points_redeemed, discount, total, status, paid_at, used_at, expired_at, cancelled_at, refunded_at, transferred_at,
+		refund_amount, refund_reason, payment_deadline,
+		payment_provider, payment_reference, payment_url, payment_instructions,
+		created_at, updated_at
+`
+
+func scanOrder(row pgx.Row) (*models.Order, error) {
+	var o models.Order
+	err := row.Scan(
+		&o.ID, &o.QRCode, &o.UserID, &o.ScheduleID, &o.PaymentID, &o.FullName, &o.Email, &o.Phone,
+		&o.Subtotal, &o.Fee, &o.PromoID, &o.PromoCode, &o.PromoDiscount, &o.PointsRedeemed, &o.Discount, &o.Total, &o.Status, &o.PaidAt, &o.UsedAt, &o.ExpiredAt, &o.CancelledAt, &o.RefundedAt, &o.TransferredAt,
+		&o.RefundAmount, &o.RefundReason, &o.PaymentDeadline,
+		&o.PaymentProvider, &o.PaymentReference, &o.PaymentURL, &o.PaymentInstructions,
+		&o.CreatedAt, &o.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// IsGuestOrder mengecek apakah order dibuat lewat checkout tamu
+func (or *OrderRepo) IsGuestOrder(ctx context.Context, orderID int) (bool, error) {
+	var guest bool
+	err := or.db.QueryRow(ctx, `
+		SELECT u.role = $2 FROM orders o JOIN users u ON u.id = o.users_id WHERE o.id = $1
+	`, orderID, models.RoleGeneral).Scan(&guest)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, ErrOrderNotFound
+	}
+	return guest, err
+}
+
+func (or *OrderRepo) GetOrder(ctx context.Context, orderID int) (*models.Order, error) {
+	return scanOrder(or.db.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1`, orderID))
+}
+
+// TransitionOrder memindahkan status order sesuai aturan transisi di models.OrderStatus
+func (or *OrderRepo) TransitionOrder(ctx context.Context, orderID int, to models.OrderStatus) (*models.Order, error) {
+	tx, err := or.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	order, err := transitionOrder(ctx, tx, orderID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func transitionOrder(ctx context.Context, tx pgx.Tx, orderID int, to models.OrderStatus) (*models.Order, error) {
+	var from models.OrderStatus
+	err := tx.QueryRow(ctx, `SELECT status FROM orders WHERE id = $1 FOR UPDATE`, orderID).Scan(&from)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !from.CanTransitionTo(to) {
+		return nil, &InvalidTransitionError{From: from, To: to}
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE orders SET status = $1, %s = NOW(), updated_at = NOW()
+		WHERE id = $2
+		RETURNING %s
+	`, orderStatusColumns[to], orderColumns)
+	order, err := scanOrder(tx.QueryRow(ctx, query, to, orderID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyOrderPoints(ctx, tx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// orderDetailSelect dipakai bersama oleh detail transaksi dan history order
+const orderDetailSelect = `
 		SELECT o.id, o.qr_code, o.users_id, o.schedules_id, o.payments_id,
-		       o.fullname, o.email, o.phone_number, o.created_at, o.updated_at,
+		       o.fullname, o.email, o.phone_number, o.subtotal, o.fee,
+		       o.promos_id, o.promo_code, o.promo_discount, o.points_redeemed, o.discount, o.total,
+		       o.status, o.paid_at, o.used_at, o.expired_at, o.cancelled_at, o.refunded_at, o.transferred_at,
+		       o.refund_amount, o.refund_reason, o.payment_deadline,
+		       o.payment_provider, o.payment_reference, o.payment_url, o.payment_instructions,
+		       o.created_at, o.updated_at,
 		       m.id, m.backdrop_path, m.overview, m.popularity, m.poster_path,
 		       m.release_date, m.duration, m.title, m.director_name,
 		       c.name as cinema_name, l.name as location, t.time, s.date,
+		       s.starts_at, s.starts_at + make_interval(mins => m.duration), l.timezone,
 		       pm.name as payment,
-		       COALESCE(json_agg(json_build_object('id', se.id, 'seat_code', se.seat_code))
+		       COALESCE(json_agg(json_build_object('id', se.id, 'seat_code', se.seat_code,
+		                                  'seat_class', se.seat_class, 'price', os.price))
 		                FILTER (WHERE se.id IS NOT NULL), '[]') as seats
 		FROM orders o
 		JOIN schedules s ON o.schedules_id = s.id
@@ -157,20 +710,30 @@ func (or *OrderRepo) GetTransactionDetail(ctx context.Context, orderID int) (*mo
 		JOIN payment_methods pm ON o.payments_id = pm.id
 		LEFT JOIN order_seats os ON o.id = os.orders_id
 		LEFT JOIN seats se ON se.id = os.seats_id
-		WHERE o.id = $1
-		GROUP BY o.id, m.id, c.name, l.name, t.time, s.date, pm.name
-	`
+`
+
+const orderDetailGroupBy = `
+		GROUP BY o.id, m.id, c.name, l.name, l.timezone, t.time, s.date, s.starts_at, pm.name
+`
 
+func scanOrderDetail(row pgx.Row) (*models.OrderDetail, error) {
 	var d models.OrderDetail
 	var seatsJSON []byte
+	var zone string
 
-	err := or.db.QueryRow(ctx, sql, orderID).Scan(
+	err := row.Scan(
 		&d.ID, &d.QRCode, &d.UserID, &d.ScheduleID, &d.PaymentID,
-		&d.FullName, &d.Email, &d.Phone, &d.CreatedAt, &d.UpdatedAt,
+		&d.FullName, &d.Email, &d.Phone, &d.Subtotal, &d.Fee,
+		&d.PromoID, &d.PromoCode, &d.PromoDiscount, &d.PointsRedeemed, &d.Discount, &d.Total,
+		&d.Status, &d.PaidAt, &d.UsedAt, &d.ExpiredAt, &d.CancelledAt, &d.RefundedAt, &d.TransferredAt,
+		&d.RefundAmount, &d.RefundReason, &d.PaymentDeadline,
+		&d.PaymentProvider, &d.PaymentReference, &d.PaymentURL, &d.PaymentInstructions,
+		&d.CreatedAt, &d.UpdatedAt,
 		&d.Movie.ID, &d.Movie.Backdrop, &d.Movie.Overview, &d.Movie.Popularity,
 		&d.Movie.Poster, &d.Movie.ReleaseDate, &d.Movie.Duration,
 		&d.Movie.Title, &d.Movie.Director,
 		&d.CinemaName, &d.Location, &d.TimeStr, &d.Date,
+		&d.StartsAt, &d.EndsAt, &zone,
 		&d.PaymentName,
 		&seatsJSON,
 	)
@@ -179,32 +742,26 @@ func (or *OrderRepo) GetTransactionDetail(ctx context.Context, orderID int) (*mo
 	}
 
 	_ = json.Unmarshal(seatsJSON, &d.Seats)
+	d.StartsAt, d.EndsAt = models.InZone(d.StartsAt, zone), models.InZone(d.EndsAt, zone)
 	return &d, nil
 }
 
+func (or *OrderRepo) GetTransactionDetail(ctx context.Context, orderID int) (*models.OrderDetail, error) {
+	sql := orderDetailSelect + `WHERE o.id = $1` + orderDetailGroupBy
+	d, err := scanOrderDetail(or.db.QueryRow(ctx, sql, orderID))
+	if err != nil {
+		return nil, err
+	}
+	details := []models.OrderDetail{*d}
+	if err := attachTransfers(ctx, or.db, details); err != nil {
+		return nil, err
+	}
+	return &details[0], nil
+}
+
 func (or *OrderRepo) GetOrderHistory(ctx context.Context, userID uuid.UUID) ([]models.OrderDetail, error) {
-	rows, err := or.db.Query(ctx, `
-		SELECT o.id, o.qr_code, o.users_id, o.schedules_id, o.payments_id,
-		       o.fullname, o.email, o.phone_number, o.created_at, o.updated_at,
-		       m.id, m.backdrop_path, m.overview, m.popularity, m.poster_path,
-		       m.release_date, m.duration, m.title, m.director_name,
-		       c.name as cinema_name, l.name as location, t.time, s.date,
-		       pm.name as payment,
-		       COALESCE(json_agg(json_build_object('id', se.id, 'seat_code', se.seat_code))
-		                FILTER (WHERE se.id IS NOT NULL), '[]') as seats
-		FROM orders o
-		JOIN schedules s ON o.schedules_id = s.id
-		JOIN movies m ON s.movies_id = m.id
-		JOIN cinemas c ON s.cinemas_id = c.id
-		JOIN locations l ON s.locations_id = l.id
-		JOIN times t ON s.times_id = t.id
-		JOIN payment_methods pm ON o.payments_id = pm.id
-		LEFT JOIN order_seats os ON o.id = os.orders_id
-		LEFT JOIN seats se ON se.id = os.seats_id
-		WHERE o.users_id = $1
-		GROUP BY o.id, m.id, c.name, l.name, t.time, s.date, pm.name
-		ORDER BY o.created_at DESC
-	`, userID)
+	sql := orderDetailSelect + `WHERE o.users_id = $1` + orderDetailGroupBy + `ORDER BY o.created_at DESC`
+	rows, err := or.db.Query(ctx, sql, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -212,23 +769,17 @@ func (or *OrderRepo) GetOrderHistory(ctx context.Context, userID uuid.UUID) ([]m
 
 	var orders []models.OrderDetail
 	for rows.Next() {
-		var d models.OrderDetail
-		var seatsJSON []byte
-
-		if err := rows.Scan(
-			&d.ID, &d.QRCode, &d.UserID, &d.ScheduleID, &d.PaymentID,
-			&d.FullName, &d.Email, &d.Phone, &d.CreatedAt, &d.UpdatedAt,
-			&d.Movie.ID, &d.Movie.Backdrop, &d.Movie.Overview, &d.Movie.Popularity,
-			&d.Movie.Poster, &d.Movie.ReleaseDate, &d.Movie.Duration,
-			&d.Movie.Title, &d.Movie.Director,
-			&d.CinemaName, &d.Location, &d.TimeStr, &d.Date,
-			&d.PaymentName,
-			&seatsJSON,
-		); err != nil {
+		d, err := scanOrderDetail(rows)
+		if err != nil {
 			return nil, err
 		}
-		_ = json.Unmarshal(seatsJSON, &d.Seats)
-		orders = append(orders, d)
+		orders = append(orders, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := attachTransfers(ctx, or.db, orders); err != nil {
+		return nil, err
 	}
 	return orders, nil
 }
@@ -0,0 +1,176 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// test ini butuh database yang sudah dimigrasi, set TEST_DBURL untuk menjalankan
+func setupOrderTestDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dbURL := os.Getenv("TEST_DBURL")
+	if dbURL == "" {
+		t.Skip("TEST_DBURL not set")
+	}
+	db, err := pgxpool.New(context.Background(), dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+	return db
+}
+
+type orderFixture struct {
+	scheduleID int
+	paymentID  int
+	userID     uuid.UUID
+	seatIDs    []int
+}
+
+func createOrderFixture(t *testing.T, db *pgxpool.Pool) orderFixture {
+	t.Helper()
+	t.Setenv("TICKET_SECRET", "test-secret")
+	ctx := context.Background()
+	var f orderFixture
+	var movieID, cinemaID, locationID, timeID, hallID int
+
+	f.userID = uuid.New()
+	steps := []struct {
+		sql  string
+		args []any
+		dest *int
+	}{
+		{`INSERT INTO movies (backdrop_path, overview, popularity, poster_path, release_date, duration, title, director_name)
+		  VALUES ('', '', 0, '', NOW(), 120, 'Concurrency Test', '') RETURNING id`, nil, &movieID},
+		{`INSERT INTO cinemas (name) VALUES ('Test Cinema') RETURNING id`, nil, &cinemaID},
+		{`INSERT INTO locations (name) VALUES ('Test Location') RETURNING id`, nil, &locationID},
+		{`INSERT INTO times (time) VALUES ('13:00') RETURNING id`, nil, &timeID},
+		{`INSERT INTO payment_methods (name) VALUES ('Test Pay') RETURNING id`, nil, &f.paymentID},
+	}
+	for _, s := range steps {
+		if err := db.QueryRow(ctx, s.sql, s.args...).Scan(s.dest); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := db.QueryRow(ctx, `
+		INSERT INTO halls (cinemas_id, name, row_count, column_count) VALUES ($1, 'Test Hall', 1, 2) RETURNING id
+	`, cinemaID).Scan(&hallID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.QueryRow(ctx, `
+		INSERT INTO schedules (movies_id, cinemas_id, times_id, locations_id, halls_id, date, starts_at, price)
+		VALUES ($1,$2,$3,$4,$5,CURRENT_DATE + 1,(CURRENT_DATE + 1 + TIME '13:00') AT TIME ZONE 'Asia/Jakarta',50000) RETURNING id
+	`, movieID, cinemaID, timeID, locationID, hallID).Scan(&f.scheduleID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.Exec(ctx, `INSERT INTO users (id, email, password, role) VALUES ($1, $2, 'x', 'user')`,
+		f.userID, f.userID.String()+"@test.local"); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, code := range []string{"A1", "A2"} {
+		var id int
+		if err := db.QueryRow(ctx, `
+			INSERT INTO seats (seat_code, halls_id, grid_row, grid_col) VALUES ($1,$2,1,$3) RETURNING id
+		`, code, hallID, i+1).Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		f.seatIDs = append(f.seatIDs, id)
+	}
+
+	t.Cleanup(func() {
+		ctx := context.Background()
+		db.Exec(ctx, `DELETE FROM order_seats WHERE orders_id IN (SELECT id FROM orders WHERE schedules_id=$1)`, f.scheduleID)
+		db.Exec(ctx, `DELETE FROM orders WHERE schedules_id=$1`, f.scheduleID)
+		db.Exec(ctx, `DELETE FROM seats WHERE id = ANY($1)`, f.seatIDs)
+		db.Exec(ctx, `DELETE FROM schedules WHERE id=$1`, f.scheduleID)
+		db.Exec(ctx, `DELETE FROM halls WHERE id=$1`, hallID)
+		db.Exec(ctx, `DELETE FROM users WHERE id=$1`, f.userID)
+		db.Exec(ctx, `DELETE FROM payment_methods WHERE id=$1`, f.paymentID)
+		db.Exec(ctx, `DELETE FROM times WHERE id=$1`, timeID)
+		db.Exec(ctx, `DELETE FROM locations WHERE id=$1`, locationID)
+		db.Exec(ctx, `DELETE FROM cinemas WHERE id=$1`, cinemaID)
+		db.Exec(ctx, `DELETE FROM movies WHERE id=$1`, movieID)
+	})
+	return f
+}
+
+func TestCreateOrderConcurrentSameSeats(t *testing.T) {
+	db := setupOrderTestDB(t)
+	f := createOrderFixture(t, db)
+	repo := NewOrderRepo(db)
+
+	const workers = 25
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		success   int
+		conflicts int
+		others    []error
+	)
+
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			order := &models.Order{
+				UserID:     f.userID,
+				ScheduleID: f.scheduleID,
+				PaymentID:  f.paymentID,
+				FullName:   "Tester",
+				Email:      "tester@test.local",
+				Phone:      "0800000000",
+			}
+			_, err := repo.CreateOrder(context.Background(), order, f.seatIDs)
+
+			mu.Lock()
+			defer mu.Unlock()
+			var conflictErr *SeatConflictError
+			switch {
+			case err == nil:
+				success++
+			case errors.Is(err, ErrSeatTaken):
+				conflicts++
+				if !errors.As(err, &conflictErr) || len(conflictErr.SeatCodes) != len(f.seatIDs) {
+					others = append(others, err)
+				}
+			default:
+				others = append(others, err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(others) > 0 {
+		t.Fatalf("unexpected errors: %v", others)
+	}
+	if success != 1 || conflicts != workers-1 {
+		t.Fatalf("expected 1 success and %d ErrSeatTaken failures, got %d and %d", workers-1, success, conflicts)
+	}
+
+	var booked int
+	err := db.QueryRow(context.Background(), `
+		SELECT COUNT(*) FROM order_seats os
+		JOIN orders o ON o.id = os.orders_id
+		WHERE o.schedules_id = $1
+	`, f.scheduleID).Scan(&booked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if booked != len(f.seatIDs) {
+		t.Fatalf("expected %d booked seats, got %d", len(f.seatIDs), booked)
+	}
+}
@@ -0,0 +1,142 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/payments"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPaymentMethodNotFound = errors.New("payment method not found")
+	ErrAmountMismatch        = errors.New("paid amount does not match order total")
+)
+
+type PaymentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPaymentRepo(db *pgxpool.Pool) *PaymentRepo {
+	return &PaymentRepo{db: db}
+}
+
+func (pr *PaymentRepo) GetPaymentMethod(ctx context.Context, paymentID int) (*models.PaymentMethod, error) {
+	var pm models.PaymentMethod
+	err := pr.db.QueryRow(ctx, `SELECT id, name, provider FROM payment_methods WHERE id = $1`, paymentID).
+		Scan(&pm.ID, &pm.Name, &pm.Provider)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPaymentMethodNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pm, nil
+}
+
+// AttachCharge menyimpan tagihan provider ke order
+func (pr *PaymentRepo) AttachCharge(ctx context.Context, order *models.Order, provider string, charge *payments.Charge) error {
+	_, err := pr.db.Exec(ctx, `
+		UPDATE orders
+		SET payment_provider = $1, payment_reference = $2, payment_url = NULLIF($3, ''),
+		    payment_instructions = NULLIF($4, ''), updated_at = NOW()
+		WHERE id = $5
+	`, provider, charge.Reference, charge.RedirectURL, charge.Instructions, order.ID)
+	if err != nil {
+		return err
+	}
+
+	order.PaymentProvider = &provider
+	order.PaymentReference = &charge.Reference
+	if charge.RedirectURL != "" {
+		order.PaymentURL = &charge.RedirectURL
+	}
+	if charge.Instructions != "" {
+		order.PaymentInstructions = &charge.Instructions
+	}
+	return nil
+}
+
+// ProcessWebhook mencatat event dari provider dan mengubah status order.
+// event yang sama hanya diproses sekali, duplicate bernilai true untuk event ulangan.
+// pembayaran yang masuk setelah order expired / dibatalkan dicatat sebagai refund pending yang dikembalikan lewat refund.
+func (pr *PaymentRepo) ProcessWebhook(ctx context.Context, provider string, event *payments.WebhookEvent, payload []byte) (order *models.Order, refund *models.Refund, duplicate bool, err error) {
+	tx, err := pr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var orderID int
+	var total int
+	var status models.OrderStatus
+	err = tx.QueryRow(ctx, `
+		SELECT id, total, status FROM orders
+		WHERE payment_provider = $1 AND payment_reference = $2
+		FOR UPDATE
+	`, provider, event.Reference).Scan(&orderID, &total, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, false, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO payment_events (provider, event_id, orders_id, status, payload)
+		VALUES ($1,$2,$3,$4,$5)
+		ON CONFLICT (provider, event_id) DO NOTHING
+	`, provider, event.EventID, orderID, event.Status, payload)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if tag.RowsAffected() == 0 {
+		order, err := scanOrder(tx.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1`, orderID))
+		return order, nil, true, err
+	}
+
+	var next models.OrderStatus
+	switch event.Status {
+	case payments.StatusPaid:
+		// pembayaran telat tidak dicek nominalnya karena seluruh dana yang masuk dikembalikan
+		if event.Amount != total && status.CanTransitionTo(models.OrderPaid) {
+			return nil, nil, false, ErrAmountMismatch
+		}
+		next = models.OrderPaid
+	case payments.StatusExpired:
+		next = models.OrderExpired
+	case payments.StatusFailed:
+		next = models.OrderCancelled
+	}
+
+	if next != "" && status.CanTransitionTo(next) {
+		order, err = transitionOrder(ctx, tx, orderID, next)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	} else {
+		if next != "" && next != status {
+			log.Printf("payment event %s for order %d ignored: order is %s\n", event.EventID, orderID, status)
+		}
+		// pelanggan sudah membayar tapi tidak mendapat tiket, dananya harus dikembalikan
+		if next == models.OrderPaid && (status == models.OrderExpired || status == models.OrderCancelled) && event.Amount > 0 {
+			reason := fmt.Sprintf("payment received after the order was %s", status)
+			if refund, err = insertRefund(ctx, tx, orderID, event.Amount, reason); err != nil {
+				return nil, nil, false, err
+			}
+		}
+		order, err = scanOrder(tx.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1`, orderID))
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, false, err
+	}
+	return order, refund, false, nil
+}
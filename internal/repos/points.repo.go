@@ -0,0 +1,141 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInsufficientPoints = errors.New("insufficient points")
+	ErrRedeemExceedsPrice = errors.New("redeemed points exceed order subtotal")
+)
+
+// pointValue adalah nilai rupiah satu poin saat ditukar, diatur lewat POINTS_VALUE
+func pointValue() int {
+	if v, err := strconv.Atoi(os.Getenv("POINTS_VALUE")); err == nil && v > 0 {
+		return v
+	}
+	return 10
+}
+
+// redeemDiscount menghitung potongan dari poin yang ditukar, dengan lock saldo poin user dikunci sampai transaksi selesai.
+// potongan hanya berlaku untuk harga tiket, biaya layanan tetap dibayar.
+func redeemDiscount(ctx context.Context, q querier, userID uuid.UUID, points, subtotal int, lock bool) (int, error) {
+	var balance int
+	err := q.QueryRow(ctx, `SELECT point FROM profile WHERE user_id = $1`+forUpdate(lock), userID).Scan(&balance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrInsufficientPoints
+	}
+	if err != nil {
+		return 0, err
+	}
+	if balance < points {
+		return 0, ErrInsufficientPoints
+	}
+
+	discount := points * pointValue()
+	if discount > subtotal {
+		return 0, ErrRedeemExceedsPrice
+	}
+	return discount, nil
+}
+
+// orderPoints menghitung poin untuk order yang dibayar.
+// POINTS_PER_TICKET memberi poin tetap per kursi, POINTS_AMOUNT_UNIT memberi 1 poin
+// untuk setiap kelipatan nominal subtotal. keduanya bisa dipakai bersamaan.
+func orderPoints(subtotal, tickets int) int {
+	perTicket, _ := strconv.Atoi(os.Getenv("POINTS_PER_TICKET"))
+	points := perTicket * tickets
+
+	unit := 1000
+	if v, err := strconv.Atoi(os.Getenv("POINTS_AMOUNT_UNIT")); err == nil {
+		unit = v
+	}
+	if unit > 0 {
+		points += subtotal / unit
+	}
+	return points
+}
+
+// addPoints mengubah saldo poin user dan mencatatnya di ledger dalam transaksi yang sama.
+// pengurangan selalu dicatat penuh walaupun saldo jadi negatif, misalnya poin order yang dibatalkan
+// sudah terlanjur ditukar di order lain. saldo negatif adalah utang poin yang dilunasi dari poin berikutnya,
+// selama itu redeemDiscount menolak penukaran. setiap kejadian tercatat di ledger walaupun delta 0.
+func addPoints(ctx context.Context, tx pgx.Tx, userID uuid.UUID, orderID *int, delta int, reason models.PointsReason) error {
+	var balance int
+	err := tx.QueryRow(ctx, `SELECT point FROM profile WHERE user_id = $1 FOR UPDATE`, userID).Scan(&balance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// admin dan staff tidak punya profile, tidak ada poin yang dicatat
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE profile SET point = point + $1, updated_at = NOW() WHERE user_id = $2`, delta, userID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO points_ledger (users_id, orders_id, delta, balance, reason)
+		VALUES ($1,$2,$3,$4,$5)
+	`, userID, orderID, delta, balance+delta, reason)
+	return err
+}
+
+// applyOrderPoints dipanggil setiap status order berubah
+func applyOrderPoints(ctx context.Context, tx pgx.Tx, order *models.Order) error {
+	switch order.Status {
+	case models.OrderPaid:
+		var tickets int
+		if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM order_seats WHERE orders_id = $1`, order.ID).Scan(&tickets); err != nil {
+			return err
+		}
+		return addPoints(ctx, tx, order.UserID, &order.ID, orderPoints(order.Subtotal-order.PromoDiscount-order.Discount, tickets), models.PointsEarned)
+
+	case models.OrderExpired:
+		return refundRedeemedPoints(ctx, tx, order)
+
+	case models.OrderCancelled, models.OrderRefunded:
+		if err := refundRedeemedPoints(ctx, tx, order); err != nil {
+			return err
+		}
+
+		// hanya order yang pernah dapat poin yang dikurangi, dan hanya sekali
+		var earned int
+		err := tx.QueryRow(ctx, `
+			SELECT delta FROM points_ledger
+			WHERE orders_id = $1 AND reason = $2
+			  AND NOT EXISTS (SELECT 1 FROM points_ledger WHERE orders_id = $1 AND reason = $3)
+		`, order.ID, models.PointsEarned, models.PointsReversed).Scan(&earned)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return addPoints(ctx, tx, order.UserID, &order.ID, -earned, models.PointsReversed)
+	}
+	return nil
+}
+
+// refundRedeemedPoints mengembalikan poin yang dipakai order yang batal, hanya sekali per order
+func refundRedeemedPoints(ctx context.Context, tx pgx.Tx, order *models.Order) error {
+	if order.PointsRedeemed == 0 {
+		return nil
+	}
+
+	var refunded bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM points_ledger WHERE orders_id = $1 AND reason = $2)
+	`, order.ID, models.PointsRedeemRefunded).Scan(&refunded)
+	if err != nil || refunded {
+		return err
+	}
+	return addPoints(ctx, tx, order.UserID, &order.ID, order.PointsRedeemed, models.PointsRedeemRefunded)
+}
@@ -88,6 +88,34 @@ func (pr *ProfileRepo) UpdateProfile(ctx context.Context, p *models.Profile) err
 	return err
 }
 
+// GetPointHistory mengembalikan saldo poin beserta riwayat perubahannya, terbaru di atas
+func (pr *ProfileRepo) GetPointHistory(ctx context.Context, userID uuid.UUID) (*models.PointsHistory, error) {
+	history := models.PointsHistory{History: []models.PointsEntry{}}
+	if err := pr.db.QueryRow(ctx, `SELECT point FROM profile WHERE user_id = $1`, userID).Scan(&history.Balance); err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.db.Query(ctx, `
+		SELECT id, users_id, orders_id, delta, balance, reason, created_at
+		FROM points_ledger
+		WHERE users_id = $1
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e models.PointsEntry
+		if err := rows.Scan(&e.ID, &e.UserID, &e.OrderID, &e.Delta, &e.Balance, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		history.History = append(history.History, e)
+	}
+	return &history, rows.Err()
+}
+
 func (pr *ProfileRepo) VerifyPassword(ctx context.Context, userID uuid.UUID, oldPassword string) (string, error) {
 	var hashedPassword string
 	sql := `SELECT password FROM users WHERE id = $1`
@@ -0,0 +1,269 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPromoNotFound   = errors.New("promo not found")
+	ErrPromoCodeExists = errors.New("promo code already exists")
+	ErrPromoScope      = errors.New("unknown movie or cinema in promo scope")
+)
+
+// PromoError dikembalikan ketika kode promo tidak bisa dipakai untuk order
+type PromoError struct {
+	Reason string
+}
+
+func (e *PromoError) Error() string {
+	return e.Reason
+}
+
+type PromoRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPromoRepo(db *pgxpool.Pool) *PromoRepo {
+	return &PromoRepo{db: db}
+}
+
+// used_count hanya menghitung order yang masih berlaku,
+// order yang expired / dibatalkan otomatis mengembalikan kuotanya
+const promoColumns = `
+		p.id, p.code, p.description, p.discount_type, p.discount_value, p.max_discount,
+		p.min_tickets, p.valid_from, p.valid_until, p.usage_limit, p.per_user_limit,
+		p.is_active, p.created_at, p.updated_at,
+		(SELECT COUNT(*) FROM orders o WHERE o.promos_id = p.id AND o.status = ANY($1)),
+		COALESCE(ARRAY(SELECT movies_id FROM promo_movies WHERE promos_id = p.id ORDER BY movies_id), '{}'),
+		COALESCE(ARRAY(SELECT cinemas_id FROM promo_cinemas WHERE promos_id = p.id ORDER BY cinemas_id), '{}')
+`
+
+func scanPromo(row pgx.Row) (*models.Promo, error) {
+	var p models.Promo
+	err := row.Scan(
+		&p.ID, &p.Code, &p.Description, &p.DiscountType, &p.DiscountValue, &p.MaxDiscount,
+		&p.MinTickets, &p.ValidFrom, &p.ValidUntil, &p.UsageLimit, &p.PerUserLimit,
+		&p.IsActive, &p.CreatedAt, &p.UpdatedAt,
+		&p.UsedCount, &p.MovieIDs, &p.CinemaIDs,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPromoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (pr *PromoRepo) GetPromos(ctx context.Context) ([]models.Promo, error) {
+	rows, err := pr.db.Query(ctx, `SELECT `+promoColumns+` FROM promos p ORDER BY p.id DESC`, seatConsumingStatuses())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promos := []models.Promo{}
+	for rows.Next() {
+		p, err := scanPromo(rows)
+		if err != nil {
+			return nil, err
+		}
+		promos = append(promos, *p)
+	}
+	return promos, rows.Err()
+}
+
+func (pr *PromoRepo) GetPromo(ctx context.Context, id int) (*models.Promo, error) {
+	return scanPromo(pr.db.QueryRow(ctx, `SELECT `+promoColumns+` FROM promos p WHERE p.id = $2`, seatConsumingStatuses(), id))
+}
+
+func (pr *PromoRepo) CreatePromo(ctx context.Context, p *models.Promo) error {
+	tx, err := pr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO promos (code, description, discount_type, discount_value, max_discount, min_tickets,
+		                    valid_from, valid_until, usage_limit, per_user_limit, is_active, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,NOW())
+		RETURNING id, created_at
+	`, p.Code, p.Description, p.DiscountType, p.DiscountValue, p.MaxDiscount, p.MinTickets,
+		p.ValidFrom, p.ValidUntil, p.UsageLimit, p.PerUserLimit, p.IsActive,
+	).Scan(&p.ID, &p.CreatedAt)
+	if isUniqueViolation(err) {
+		return ErrPromoCodeExists
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := setPromoScope(ctx, tx, p.ID, p.MovieIDs, p.CinemaIDs); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UpdatePromo hanya mengubah kolom yang dikirim. movieIDs / cinemaIDs nil berarti tidak diubah,
+// slice kosong berarti promo berlaku untuk semua movie / cinema.
+func (pr *PromoRepo) UpdatePromo(ctx context.Context, id int, fields map[string]any, movieIDs, cinemaIDs []int) error {
+	tx, err := pr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	setParts := []string{}
+	args := []any{}
+	for column, value := range fields {
+		args = append(args, value)
+		setParts = append(setParts, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	setParts = append(setParts, "updated_at = NOW()")
+	args = append(args, id)
+
+	sql := fmt.Sprintf(`UPDATE promos SET %s WHERE id = $%d`, strings.Join(setParts, ", "), len(args))
+	tag, err := tx.Exec(ctx, sql, args...)
+	if isUniqueViolation(err) {
+		return ErrPromoCodeExists
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPromoNotFound
+	}
+
+	if err := setPromoScope(ctx, tx, id, movieIDs, cinemaIDs); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func setPromoScope(ctx context.Context, tx pgx.Tx, promoID int, movieIDs, cinemaIDs []int) error {
+	if movieIDs != nil {
+		if _, err := tx.Exec(ctx, `DELETE FROM promo_movies WHERE promos_id = $1`, promoID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO promo_movies (promos_id, movies_id)
+			SELECT $1, UNNEST($2::int[]) ON CONFLICT DO NOTHING
+		`, promoID, movieIDs); err != nil {
+			return promoScopeError(err)
+		}
+	}
+	if cinemaIDs != nil {
+		if _, err := tx.Exec(ctx, `DELETE FROM promo_cinemas WHERE promos_id = $1`, promoID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO promo_cinemas (promos_id, cinemas_id)
+			SELECT $1, UNNEST($2::int[]) ON CONFLICT DO NOTHING
+		`, promoID, cinemaIDs); err != nil {
+			return promoScopeError(err)
+		}
+	}
+	return nil
+}
+
+func (pr *PromoRepo) DeletePromo(ctx context.Context, id int) error {
+	tag, err := pr.db.Exec(ctx, `DELETE FROM promos WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPromoNotFound
+	}
+	return nil
+}
+
+// applyPromo memvalidasi order.PromoCode untuk order dan mengisi potongannya.
+// dengan lock, baris promo dikunci FOR UPDATE sampai transaksi selesai supaya penghitungan kuota
+// tidak balapan ketika banyak checkout memakai kode yang sama bersamaan.
+func applyPromo(ctx context.Context, q querier, order *models.Order, now time.Time, lock bool) error {
+	var promoID int
+	err := q.QueryRow(ctx, `SELECT id FROM promos WHERE UPPER(code) = UPPER($1) AND is_active`+forUpdate(lock), *order.PromoCode).Scan(&promoID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &PromoError{Reason: "promo code not found"}
+	}
+	if err != nil {
+		return err
+	}
+
+	promo, err := scanPromo(q.QueryRow(ctx, `SELECT `+promoColumns+` FROM promos p WHERE p.id = $2`, seatConsumingStatuses(), promoID))
+	if err != nil {
+		return err
+	}
+
+	var movieID, cinemaID int
+	if err := q.QueryRow(ctx, `SELECT movies_id, cinemas_id FROM schedules WHERE id = $1`, order.ScheduleID).Scan(&movieID, &cinemaID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrScheduleNotFound
+		}
+		return err
+	}
+
+	switch {
+	case promo.ValidFrom != nil && now.Before(*promo.ValidFrom):
+		return &PromoError{Reason: "promo code is not active yet"}
+	case promo.ValidUntil != nil && now.After(*promo.ValidUntil):
+		return &PromoError{Reason: "promo code has expired"}
+	case len(order.Seats) < promo.MinTickets:
+		return &PromoError{Reason: fmt.Sprintf("promo code requires at least %d tickets", promo.MinTickets)}
+	case len(promo.MovieIDs) > 0 && !slices.Contains(promo.MovieIDs, movieID):
+		return &PromoError{Reason: "promo code is not valid for this movie"}
+	case len(promo.CinemaIDs) > 0 && !slices.Contains(promo.CinemaIDs, cinemaID):
+		return &PromoError{Reason: "promo code is not valid for this cinema"}
+	case promo.UsageLimit != nil && promo.UsedCount >= *promo.UsageLimit:
+		return &PromoError{Reason: "promo code usage limit reached"}
+	}
+
+	if promo.PerUserLimit != nil {
+		used, err := promoUsedBy(ctx, q, promo.ID, order.UserID)
+		if err != nil {
+			return err
+		}
+		if used >= *promo.PerUserLimit {
+			return &PromoError{Reason: "promo code usage limit for this account reached"}
+		}
+	}
+
+	order.PromoID = &promo.ID
+	order.PromoCode = &promo.Code
+	order.PromoDiscount = promo.Discount(order.Subtotal)
+	return nil
+}
+
+func promoUsedBy(ctx context.Context, q querier, promoID int, userID uuid.UUID) (int, error) {
+	var used int
+	err := q.QueryRow(ctx, `
+		SELECT COUNT(*) FROM orders WHERE promos_id = $1 AND users_id = $2 AND status = ANY($3)
+	`, promoID, userID, seatConsumingStatuses()).Scan(&used)
+	return used, err
+}
+
+// promoScopeError mengubah pelanggaran foreign key jadi ErrPromoScope
+func promoScopeError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return ErrPromoScope
+	}
+	return err
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,40 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RateLimitRepo struct {
+	redis *redis.Client
+}
+
+func NewRateLimitRepo(redis *redis.Client) *RateLimitRepo {
+	return &RateLimitRepo{redis: redis}
+}
+
+// Allow menghitung request per key dalam jendela waktu tetap, false kalau sudah melewati limit.
+// sisa waktu sampai jendela direset ikut dikembalikan untuk header Retry-After.
+func (rr *RateLimitRepo) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	redisKey := fmt.Sprintf("ratelimit:%s", key)
+
+	count, err := rr.redis.Incr(ctx, redisKey).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	ttl, err := rr.redis.PTTL(ctx, redisKey).Result()
+	if err != nil {
+		return false, 0, err
+	}
+	// jendela dimulai di request pertama, key tanpa expire (gagal dipasang sebelumnya) ikut diperbaiki
+	if ttl < 0 {
+		if err := rr.redis.PExpire(ctx, redisKey, window).Err(); err != nil {
+			return false, 0, err
+		}
+		ttl = window
+	}
+	return count <= int64(limit), ttl, nil
+}
@@ -0,0 +1,197 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrRefundClosed = errors.New("order can no longer be refunded")
+	ErrRefundAmount = errors.New("refund amount exceeds order total")
+	// refund tidak ada atau sudah berhasil sebelumnya
+	ErrRefundNotFound = errors.New("pending refund not found")
+)
+
+// refund penuh sampai 24 jam sebelum tayang, 50% sampai 2 jam sebelum tayang
+const defaultRefundPolicy = "24h:100,2h:50"
+
+// refundPolicy dibaca dari REFUND_POLICY, format "24h:100,2h:50"
+func refundPolicy() models.RefundPolicy {
+	if v := os.Getenv("REFUND_POLICY"); v != "" {
+		policy, err := models.ParseRefundPolicy(v)
+		if err == nil {
+			return policy
+		}
+		log.Println("invalid REFUND_POLICY, using default:", err)
+	}
+	policy, _ := models.ParseRefundPolicy(defaultRefundPolicy)
+	return policy
+}
+
+// alasan refund kalau pembatalan tidak menyertakan alasan
+const orderCancelledReason = "order cancelled"
+
+// CancelOrder membatalkan order dan mencatat jumlah serta alasan refund.
+// order pending cukup dibatalkan, order paid direfund sesuai kebijakan refund kecuali amount diisi (override admin).
+// refund dicatat pending di transaksi yang sama, pemanggilan provider dilakukan setelah commit
+// supaya dana yang sudah dikembalikan provider tidak pernah tertinggal tanpa catatan kalau commit gagal.
+func (or *OrderRepo) CancelOrder(ctx context.Context, orderID int, reason string, amount *int) (*models.Order, *models.Refund, error) {
+	tx, err := or.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		status   models.OrderStatus
+		total    int
+		subtotal int
+		seatsSum int
+		startsAt time.Time
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT o.status, o.total, o.subtotal,
+		       (SELECT COALESCE(SUM(os.price), 0) FROM order_seats os WHERE os.orders_id = o.id),
+		       s.starts_at
+		FROM orders o
+		JOIN schedules s ON s.id = o.schedules_id
+		WHERE o.id = $1
+		FOR UPDATE OF o
+	`, orderID).Scan(&status, &total, &subtotal, &seatsSum, &startsAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to := models.OrderCancelled
+	refundAmount := 0
+	if status == models.OrderPaid {
+		if amount != nil {
+			if *amount > total {
+				return nil, nil, ErrRefundAmount
+			}
+			refundAmount = *amount
+		} else {
+			// kursi yang sudah ditransfer ke user lain tidak ikut direfund
+			refundable := total
+			if subtotal > 0 && seatsSum < subtotal {
+				refundable = total * seatsSum / subtotal
+			}
+			refundAmount = refundPolicy().Amount(refundable, time.Until(startsAt))
+			if refundAmount == 0 {
+				return nil, nil, ErrRefundClosed
+			}
+		}
+		if refundAmount > 0 {
+			to = models.OrderRefunded
+		}
+	}
+
+	order, err := transitionOrder(ctx, tx, orderID, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var refundReason *string
+	if reason != "" {
+		refundReason = &reason
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE orders SET refund_amount = $1, refund_reason = $2 WHERE id = $3
+	`, refundAmount, refundReason, orderID); err != nil {
+		return nil, nil, err
+	}
+	order.RefundAmount = refundAmount
+	order.RefundReason = refundReason
+
+	var refund *models.Refund
+	if refundAmount > 0 {
+		refundNote := reason
+		if refundNote == "" {
+			refundNote = orderCancelledReason
+		}
+		if refund, err = insertRefund(ctx, tx, orderID, refundAmount, refundNote); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return order, refund, nil
+}
+
+const refundColumns = `id, orders_id, provider, payment_reference, amount, reason, status, attempts, last_error, created_at, refunded_at`
+
+func scanRefund(row pgx.Row) (*models.Refund, error) {
+	var r models.Refund
+	err := row.Scan(&r.ID, &r.OrderID, &r.Provider, &r.PaymentReference, &r.Amount, &r.Reason, &r.Status,
+		&r.Attempts, &r.LastError, &r.CreatedAt, &r.RefundedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRefundNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// insertRefund mencatat refund pending untuk tagihan provider order, dipanggil di transaksi yang sama
+// dengan perubahan status order supaya dana yang harus dikembalikan tidak pernah hilang
+func insertRefund(ctx context.Context, q querier, orderID, amount int, reason string) (*models.Refund, error) {
+	return scanRefund(q.QueryRow(ctx, `
+		INSERT INTO refunds (orders_id, provider, payment_reference, amount, reason)
+		SELECT id, payment_provider, payment_reference, $2, $3 FROM orders WHERE id = $1
+		RETURNING `+refundColumns, orderID, amount, reason))
+}
+
+// GetRefunds mengembalikan refund terbaru lebih dulu, status kosong berarti semua
+func (pr *PaymentRepo) GetRefunds(ctx context.Context, status string) ([]models.Refund, error) {
+	rows, err := pr.db.Query(ctx, `
+		SELECT `+refundColumns+` FROM refunds
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC, id DESC
+	`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := []models.Refund{}
+	for rows.Next() {
+		r, err := scanRefund(rows)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, *r)
+	}
+	return refunds, rows.Err()
+}
+
+func (pr *PaymentRepo) GetRefund(ctx context.Context, id int) (*models.Refund, error) {
+	return scanRefund(pr.db.QueryRow(ctx, `SELECT `+refundColumns+` FROM refunds WHERE id = $1`, id))
+}
+
+// RecordRefundAttempt mencatat hasil pemanggilan provider, refund yang gagal tetap pending
+func (pr *PaymentRepo) RecordRefundAttempt(ctx context.Context, id int, refundErr error) (*models.Refund, error) {
+	var lastError *string
+	status := models.RefundSucceeded
+	if refundErr != nil {
+		msg := refundErr.Error()
+		lastError, status = &msg, models.RefundPending
+	}
+	return scanRefund(pr.db.QueryRow(ctx, `
+		UPDATE refunds
+		SET status = $2, attempts = attempts + 1, last_error = $3,
+		    refunded_at = CASE WHEN $2 = 'succeeded' THEN NOW() END
+		WHERE id = $1 AND status = 'pending'
+		RETURNING `+refundColumns, id, status, lastError))
+}
@@ -0,0 +1,598 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrScheduleInUse     = errors.New("schedule already has orders, cancel it instead")
+	ErrScheduleCancelled = errors.New("schedule is cancelled")
+	ErrScheduleHall      = errors.New("hall does not belong to the cinema")
+	ErrScheduleReference = errors.New("unknown movie, cinema, location or time")
+	ErrScheduleTime      = errors.New("show time cannot be read, use a format like 19:30")
+)
+
+// ScheduleConflictError dikembalikan ketika jam tayang bertabrakan dengan schedule lain di studio yang sama
+type ScheduleConflictError struct {
+	Conflicts []models.ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("schedule overlaps %d existing schedule(s) in the same hall", len(e.Conflicts))
+}
+
+type ScheduleRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewScheduleRepo(db *pgxpool.Pool) *ScheduleRepo {
+	return &ScheduleRepo{db: db}
+}
+
+// scheduleDetailSelect memakai $1 untuk status order yang menghabiskan kursi
+const scheduleDetailSelect = `
+	SELECT s.id, s.movies_id, s.cinemas_id, s.times_id, s.locations_id, s.halls_id, s.date, s.price, s.cancelled_at,
+	       s.starts_at, s.starts_at + make_interval(mins => m.duration),
+	       m.title, c.name, l.name, l.timezone, h.name, t.time,
+	       (
+	           SELECT COUNT(*)
+	           FROM orders o
+	           JOIN order_seats os ON os.orders_id = o.id
+	           WHERE o.schedules_id = s.id AND o.status = ANY($1)
+	       ) AS sold_seats,
+	       (SELECT COUNT(*) FROM seats se WHERE se.halls_id = s.halls_id AND NOT se.is_blocked) AS total_seats
+	FROM schedules s
+	JOIN movies m ON m.id = s.movies_id
+	JOIN cinemas c ON c.id = s.cinemas_id
+	JOIN locations l ON l.id = s.locations_id
+	JOIN halls h ON h.id = s.halls_id
+	JOIN times t ON t.id = s.times_id
+`
+
+func scanScheduleDetail(row pgx.Row) (*models.ScheduleDetail, error) {
+	var (
+		d                models.ScheduleDetail
+		startsAt, endsAt time.Time
+	)
+	err := row.Scan(
+		&d.ID, &d.MovieID, &d.CinemaID, &d.TimeID, &d.LocationID, &d.HallID, &d.Date, &d.Price, &d.CancelledAt,
+		&startsAt, &endsAt,
+		&d.MovieTitle, &d.CinemaName, &d.LocationName, &d.Timezone, &d.HallName, &d.Time, &d.SoldSeats, &d.TotalSeats,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.SetShowtime(startsAt, endsAt, d.Timezone)
+	return &d, nil
+}
+
+func (sr *ScheduleRepo) GetSchedules(ctx context.Context, f models.ScheduleFilter) ([]models.ScheduleDetail, error) {
+	rows, err := sr.db.Query(ctx, scheduleDetailSelect+`
+		WHERE ($2 = 0 OR s.movies_id = $2)
+		  AND ($3 = 0 OR s.cinemas_id = $3)
+		  AND ($4 = 0 OR s.locations_id = $4)
+		  AND ($5 = 0 OR s.halls_id = $5)
+		  AND ($6::date IS NULL OR s.date >= $6)
+		  AND ($7::date IS NULL OR s.date <= $7)
+		  AND ($8 = '' OR ($8 = 'active' AND s.cancelled_at IS NULL AND m.deleted_at IS NULL) OR ($8 = 'cancelled' AND s.cancelled_at IS NOT NULL))
+		  AND (NOT $9 OR s.starts_at > NOW())
+		ORDER BY s.date, s.starts_at, s.id
+	`, seatConsumingStatuses(), f.MovieID, f.CinemaID, f.LocationID, f.HallID, f.DateFrom, f.DateTo, f.Status, f.Upcoming)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := []models.ScheduleDetail{}
+	for rows.Next() {
+		d, err := scanScheduleDetail(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *d)
+	}
+	return schedules, rows.Err()
+}
+
+func (sr *ScheduleRepo) GetSchedule(ctx context.Context, id int) (*models.ScheduleDetail, error) {
+	d, err := scanScheduleDetail(sr.db.QueryRow(ctx, scheduleDetailSelect+`WHERE s.id = $2`, seatConsumingStatuses(), id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	return d, err
+}
+
+// CreateSchedules menyimpan beberapa schedule sekaligus, gagal satu berarti tidak ada yang tersimpan
+func (sr *ScheduleRepo) CreateSchedules(ctx context.Context, schedules []models.Schedule) error {
+	tx, err := sr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for i := range schedules {
+		if err := insertSchedule(ctx, tx, &schedules[i]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// CreateRecurringSchedules menyimpan schedule hasil aturan berulang dalam satu transaksi.
+// semua schedule dicek bentrokannya dulu, kalau ada yang bentrok atau dryRun tidak ada yang disimpan.
+// hasil preview selalu dikembalikan supaya admin bisa melihat schedule mana yang bermasalah.
+func (sr *ScheduleRepo) CreateRecurringSchedules(ctx context.Context, schedules []models.Schedule, dryRun bool) ([]models.SchedulePreview, error) {
+	tx, err := sr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	previews := make([]models.SchedulePreview, 0, len(schedules))
+	conflicted := false
+	for i := range schedules {
+		s := &schedules[i]
+		if err := resolveScheduleHall(ctx, tx, s); err != nil {
+			return nil, err
+		}
+
+		preview := models.SchedulePreview{}
+		err := checkScheduleConflicts(ctx, tx, s)
+		var conflictErr *ScheduleConflictError
+		switch {
+		case errors.As(err, &conflictErr):
+			preview.Conflicts = conflictErr.Conflicts
+			conflicted = true
+		case err != nil:
+			return nil, err
+		default:
+			// tetap disimpan di transaksi supaya schedule berikutnya dicek terhadap yang ini
+			if err := insertScheduleRow(ctx, tx, s); err != nil {
+				return nil, err
+			}
+		}
+
+		preview.Schedule = *s
+		previews = append(previews, preview)
+	}
+
+	if dryRun || conflicted {
+		// id dari transaksi yang dibatalkan tidak pernah ada, termasuk di bentrokan antar schedule dalam batch ini
+		batch := make([]int, 0, len(previews))
+		for i := range previews {
+			if previews[i].ID != 0 {
+				batch = append(batch, previews[i].ID)
+			}
+			previews[i].ID = 0
+		}
+		for i := range previews {
+			for j := range previews[i].Conflicts {
+				if slices.Contains(batch, previews[i].Conflicts[j].ScheduleID) {
+					previews[i].Conflicts[j].ScheduleID = 0
+				}
+			}
+		}
+		if conflicted {
+			return previews, &ScheduleConflictError{Conflicts: previewConflicts(previews)}
+		}
+		return previews, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return previews, nil
+}
+
+// previewConflicts mengumpulkan schedule tersimpan yang bentrok dengan batch, tanpa duplikat
+func previewConflicts(previews []models.SchedulePreview) []models.ScheduleConflict {
+	var conflicts []models.ScheduleConflict
+	for _, p := range previews {
+		for _, c := range p.Conflicts {
+			// bentrokan dengan schedule lain di batch yang sama sudah terlihat di preview masing-masing
+			if c.ScheduleID == 0 {
+				continue
+			}
+			if !slices.ContainsFunc(conflicts, func(x models.ScheduleConflict) bool { return x.ScheduleID == c.ScheduleID }) {
+				conflicts = append(conflicts, c)
+			}
+		}
+	}
+	return conflicts
+}
+
+// UpdateSchedule menyimpan perubahan schedule.
+// studio tidak bisa dipindah setelah ada kursi terjual karena kursinya milik studio lama.
+func (sr *ScheduleRepo) UpdateSchedule(ctx context.Context, s *models.Schedule) error {
+	tx, err := sr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// kunci yang sama dengan pembuatan order supaya tidak ada kursi terjual di tengah pemindahan studio
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('schedule_seats'), $1)`, s.ID); err != nil {
+		return err
+	}
+
+	var (
+		hallID    int
+		cancelled bool
+	)
+	err = tx.QueryRow(ctx, `SELECT halls_id, cancelled_at IS NOT NULL FROM schedules WHERE id = $1 FOR UPDATE`, s.ID).Scan(&hallID, &cancelled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrScheduleNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if cancelled {
+		return ErrScheduleCancelled
+	}
+
+	if err := resolveScheduleHall(ctx, tx, s); err != nil {
+		return err
+	}
+	if err := checkScheduleConflicts(ctx, tx, s); err != nil {
+		return err
+	}
+	if s.HallID != hallID {
+		sold, err := scheduleHasOrders(ctx, tx, s.ID, seatConsumingStatuses())
+		if err != nil {
+			return err
+		}
+		if sold {
+			return ErrScheduleInUse
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE schedules SET cinemas_id = $1, locations_id = $2, halls_id = $3, times_id = $4, date = $5, price = $6, starts_at = $7
+		WHERE id = $8
+	`, s.CinemaID, s.LocationID, s.HallID, s.TimeID, s.Date, s.Price, s.StartsAt, s.ID)
+	if err != nil {
+		return scheduleError(err)
+	}
+	return tx.Commit(ctx)
+}
+
+// ScheduleCancellation berisi order dan kursi yang terdampak pembatalan schedule
+type ScheduleCancellation struct {
+	CancelledOrders []int
+	RefundedOrders  []int
+	// kursi dari order yang dibatalkan / direfund, dilepas ke seat picker
+	SeatIDs []int
+	// refund pending yang harus diteruskan ke payment provider setelah commit
+	Refunds []*models.Refund
+}
+
+// alasan refund untuk order lunas ketika penayangannya dibatalkan
+const scheduleCancelledReason = "schedule cancelled"
+
+// CancelSchedule menandai schedule batal sehingga tidak bisa dipesan lagi.
+// order pending ikut dibatalkan, order yang sudah dibayar direfund penuh dan refund-nya dicatat
+// sebagai pending di transaksi yang sama supaya tidak ada dana yang terlewat kalau provider gagal.
+func (sr *ScheduleRepo) CancelSchedule(ctx context.Context, id int) (*ScheduleCancellation, error) {
+	tx, err := sr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('schedule_seats'), $1)`, id); err != nil {
+		return nil, err
+	}
+
+	var cancelled bool
+	err = tx.QueryRow(ctx, `SELECT cancelled_at IS NOT NULL FROM schedules WHERE id = $1 FOR UPDATE`, id).Scan(&cancelled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cancelled {
+		return nil, ErrScheduleCancelled
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE schedules SET cancelled_at = NOW() WHERE id = $1`, id); err != nil {
+		return nil, err
+	}
+
+	result := &ScheduleCancellation{CancelledOrders: []int{}, RefundedOrders: []int{}, Refunds: []*models.Refund{}}
+
+	pending, err := scheduleOrderIDs(ctx, tx, id, models.OrderPending)
+	if err != nil {
+		return nil, err
+	}
+	for _, orderID := range pending {
+		if _, err := transitionOrder(ctx, tx, orderID, models.OrderCancelled); err != nil {
+			return nil, err
+		}
+		result.CancelledOrders = append(result.CancelledOrders, orderID)
+	}
+
+	paid, err := scheduleOrderIDs(ctx, tx, id, models.OrderPaid)
+	if err != nil {
+		return nil, err
+	}
+	// order yang seluruh kursinya sudah ditransfer tetap menyimpan pembayaran pengirim
+	transferred, err := scheduleOrderIDs(ctx, tx, id, models.OrderTransferred)
+	if err != nil {
+		return nil, err
+	}
+	for _, orderID := range slices.Concat(paid, transferred) {
+		refund, err := refundCancelledShow(ctx, tx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		if refund == nil {
+			// order hasil transfer tidak punya nilai bayar, cukup dibatalkan
+			result.CancelledOrders = append(result.CancelledOrders, orderID)
+			continue
+		}
+		result.RefundedOrders = append(result.RefundedOrders, orderID)
+		result.Refunds = append(result.Refunds, refund)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT seats_id FROM order_seats WHERE orders_id = ANY($1) ORDER BY seats_id
+	`, slices.Concat(result.CancelledOrders, result.RefundedOrders))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var seatID int
+		if err := rows.Scan(&seatID); err != nil {
+			return nil, err
+		}
+		result.SeatIDs = append(result.SeatIDs, seatID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// refundCancelledShow mengembalikan seluruh total order lunas karena penayangannya batal.
+// mengembalikan nil kalau order tidak punya nilai bayar.
+func refundCancelledShow(ctx context.Context, tx pgx.Tx, orderID int) (*models.Refund, error) {
+	var total int
+	if err := tx.QueryRow(ctx, `SELECT total FROM orders WHERE id = $1`, orderID).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	to := models.OrderRefunded
+	if total == 0 {
+		to = models.OrderCancelled
+	}
+	if _, err := transitionOrder(ctx, tx, orderID, to); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE orders SET refund_amount = $1, refund_reason = $2 WHERE id = $3
+	`, total, scheduleCancelledReason, orderID); err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, nil
+	}
+	return insertRefund(ctx, tx, orderID, total, scheduleCancelledReason)
+}
+
+// DeleteSchedule menghapus schedule yang belum pernah dipesan
+func (sr *ScheduleRepo) DeleteSchedule(ctx context.Context, id int) error {
+	tx, err := sr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('schedule_seats'), $1)`, id); err != nil {
+		return err
+	}
+
+	sold, err := scheduleHasOrders(ctx, tx, id, seatConsumingStatuses())
+	if err != nil {
+		return err
+	}
+	if sold {
+		return ErrScheduleInUse
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM schedules WHERE id = $1`, id)
+	if err != nil {
+		return scheduleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrScheduleNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// insertSchedule menyimpan satu schedule, dipakai form movie maupun API schedule admin
+func insertSchedule(ctx context.Context, tx pgx.Tx, s *models.Schedule) error {
+	if err := resolveScheduleHall(ctx, tx, s); err != nil {
+		return err
+	}
+	if err := checkScheduleConflicts(ctx, tx, s); err != nil {
+		return err
+	}
+	return insertScheduleRow(ctx, tx, s)
+}
+
+func insertScheduleRow(ctx context.Context, tx pgx.Tx, s *models.Schedule) error {
+	err := tx.QueryRow(ctx, `
+		INSERT INTO schedules (movies_id, cinemas_id, locations_id, times_id, date, price, halls_id, starts_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		RETURNING id
+	`, s.MovieID, s.CinemaID, s.LocationID, s.TimeID, s.Date, s.Price, s.HallID, s.StartsAt).Scan(&s.ID)
+	if err != nil {
+		return scheduleError(err)
+	}
+	return nil
+}
+
+// resolveScheduleHall memastikan studio milik cinema schedule.
+// tanpa hall_id, schedule diputar di studio pertama cinema tersebut.
+func resolveScheduleHall(ctx context.Context, tx pgx.Tx, s *models.Schedule) error {
+	if s.HallID == 0 {
+		err := tx.QueryRow(ctx, `SELECT id FROM halls WHERE cinemas_id = $1 ORDER BY id LIMIT 1`, s.CinemaID).Scan(&s.HallID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrHallNotFound
+		}
+		return err
+	}
+
+	var cinemaID int
+	err := tx.QueryRow(ctx, `SELECT cinemas_id FROM halls WHERE id = $1`, s.HallID).Scan(&cinemaID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrHallNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if cinemaID != s.CinemaID {
+		return ErrScheduleHall
+	}
+	return nil
+}
+
+// cleaningBuffer adalah jeda minimal antar penayangan di satu studio (SCHEDULE_CLEANING_BUFFER), default 15 menit
+func cleaningBuffer() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("SCHEDULE_CLEANING_BUFFER")); err == nil && d >= 0 {
+		return d
+	}
+	return 15 * time.Minute
+}
+
+// checkScheduleConflicts menolak schedule yang jam tayangnya, ditambah durasi movie dan jeda bersih-bersih,
+// bertabrakan dengan schedule aktif lain di studio yang sama. schedule yang baru disimpan di transaksi
+// yang sama ikut dicek sehingga bulk create juga tidak bisa saling bertabrakan.
+// jam mulai dan selesai s diisi dari tanggal dan jam tayang di zona waktu lokasinya.
+func checkScheduleConflicts(ctx context.Context, tx pgx.Tx, s *models.Schedule) error {
+	// dua admin yang menambah schedule ke studio yang sama diproses bergantian
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('hall_schedules'), $1)`, s.HallID); err != nil {
+		return err
+	}
+
+	var (
+		duration    int
+		clock, zone string
+	)
+	err := tx.QueryRow(ctx, `
+		SELECT m.duration, t.time, l.timezone FROM movies m, times t, locations l
+		WHERE m.id = $1 AND t.id = $2 AND l.id = $3
+	`, s.MovieID, s.TimeID, s.LocationID).Scan(&duration, &clock, &zone)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrScheduleReference
+	}
+	if err != nil {
+		return err
+	}
+	loc, err := models.LoadZone(zone)
+	if err != nil {
+		return fmt.Errorf("location %d: %w", s.LocationID, err)
+	}
+	start, err := models.ShowTime(s.Date, clock, loc)
+	if err != nil {
+		return ErrScheduleTime
+	}
+	showing := models.NewShowing(start, duration)
+	s.StartsAt, s.EndsAt = showing.Start, showing.End
+
+	// penayangan yang mulai sehari sebelumnya masih bisa berjalan saat schedule ini mulai
+	rows, err := tx.Query(ctx, `
+		SELECT s.id, s.movies_id, m.title, s.halls_id, s.date, t.time, s.starts_at, m.duration
+		FROM schedules s
+		JOIN movies m ON m.id = s.movies_id
+		JOIN times t ON t.id = s.times_id
+		WHERE s.halls_id = $1 AND s.id <> $2 AND s.cancelled_at IS NULL
+		  AND s.starts_at > $3::timestamptz - INTERVAL '1 day' AND s.starts_at < $4
+		ORDER BY s.starts_at
+	`, s.HallID, s.ID, showing.Start, showing.End.Add(cleaningBuffer()))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	buffer := cleaningBuffer()
+	var conflicts []models.ScheduleConflict
+	for rows.Next() {
+		var (
+			c        models.ScheduleConflict
+			startsAt time.Time
+			minutes  int
+		)
+		if err := rows.Scan(&c.ScheduleID, &c.MovieID, &c.MovieTitle, &c.HallID, &c.Date, &c.Time, &startsAt, &minutes); err != nil {
+			return err
+		}
+
+		other := models.NewShowing(startsAt.In(loc), minutes)
+		if showing.Overlaps(other, buffer) {
+			c.StartsAt, c.EndsAt = other.Start, other.End
+			conflicts = append(conflicts, c)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+func scheduleHasOrders(ctx context.Context, q querier, scheduleID int, statuses []string) (bool, error) {
+	var exists bool
+	err := q.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM orders WHERE schedules_id = $1 AND status = ANY($2))
+	`, scheduleID, statuses).Scan(&exists)
+	return exists, err
+}
+
+func scheduleOrderIDs(ctx context.Context, q querier, scheduleID int, status models.OrderStatus) ([]int, error) {
+	rows, err := q.Query(ctx, `SELECT id FROM orders WHERE schedules_id = $1 AND status = $2 ORDER BY id`, scheduleID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// scheduleError menerjemahkan pelanggaran foreign key tabel schedules
+func scheduleError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23503" {
+		return err
+	}
+	// orders masih menunjuk ke schedule yang mau dihapus
+	if pgErr.TableName != "schedules" {
+		return ErrScheduleInUse
+	}
+	return ErrScheduleReference
+}
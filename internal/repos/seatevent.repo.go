@@ -0,0 +1,179 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	seatEventChannelPrefix = "seats:schedule:"
+	// hold yang habis ttl-nya dikabarkan redis lewat keyspace notification.
+	// syarat deployment: redis harus dijalankan dengan notify-keyspace-events berisi "Ex"
+	// (mis. redis-server --notify-keyspace-events Ex), tanpa itu kursi yang hold-nya habis
+	// baru terlihat available setelah client memuat ulang seat map.
+	// REDIS_SET_KEYSPACE_EVENTS=true membuat server menyalakannya sendiri lewat CONFIG SET,
+	// hanya untuk redis yang mengizinkan perintah CONFIG.
+	seatHoldExpiredChannel = "__keyevent@*__:expired"
+)
+
+// jeda sebelum subscribe ulang ketika koneksi pub/sub putus
+const (
+	seatEventMinBackoff = time.Second
+	seatEventMaxBackoff = 30 * time.Second
+)
+
+func seatEventChannel(scheduleID int) string {
+	return seatEventChannelPrefix + strconv.Itoa(scheduleID)
+}
+
+// SeatEventRepo menyebarkan perubahan status kursi lewat redis pub/sub ke semua instance server.
+// tiap instance cukup punya satu koneksi subscribe, event diteruskan ke subscriber lokal per schedule.
+type SeatEventRepo struct {
+	redis *redis.Client
+
+	once sync.Once
+	mu   sync.Mutex
+	subs map[int]map[chan models.SeatEvent]struct{}
+}
+
+func NewSeatEventRepo(redis *redis.Client) *SeatEventRepo {
+	return &SeatEventRepo{redis: redis, subs: map[int]map[chan models.SeatEvent]struct{}{}}
+}
+
+func (sr *SeatEventRepo) Publish(ctx context.Context, event models.SeatEvent) error {
+	if len(event.SeatIDs) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return sr.redis.Publish(ctx, seatEventChannel(event.ScheduleID), data).Err()
+}
+
+// Subscribe mendaftarkan listener untuk satu schedule, fungsi yang dikembalikan wajib dipanggil saat selesai
+func (sr *SeatEventRepo) Subscribe(scheduleID int) (<-chan models.SeatEvent, func()) {
+	sr.once.Do(func() { go sr.listen(context.Background()) })
+
+	ch := make(chan models.SeatEvent, 32)
+	sr.mu.Lock()
+	if sr.subs[scheduleID] == nil {
+		sr.subs[scheduleID] = map[chan models.SeatEvent]struct{}{}
+	}
+	sr.subs[scheduleID][ch] = struct{}{}
+	sr.mu.Unlock()
+
+	return ch, func() {
+		sr.mu.Lock()
+		defer sr.mu.Unlock()
+		delete(sr.subs[scheduleID], ch)
+		if len(sr.subs[scheduleID]) == 0 {
+			delete(sr.subs, scheduleID)
+		}
+	}
+}
+
+// listen berjalan selama proses hidup, subscribe ulang dengan backoff kalau koneksi ke redis putus
+func (sr *SeatEventRepo) listen(ctx context.Context) {
+	checkExpiredEvents(ctx, sr.redis)
+
+	backoff := seatEventMinBackoff
+	for {
+		started := time.Now()
+		err := sr.receive(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		// koneksi yang sempat stabil mulai lagi dari jeda terpendek
+		if time.Since(started) > seatEventMaxBackoff {
+			backoff = seatEventMinBackoff
+		}
+		log.Printf("seat event subscription lost: %v, retrying in %s\n", err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff = min(backoff*2, seatEventMaxBackoff)
+	}
+}
+
+// receive subscribe ke channel event kursi dan meneruskan pesannya sampai terjadi error
+func (sr *SeatEventRepo) receive(ctx context.Context) error {
+	pubsub := sr.redis.PSubscribe(ctx, seatEventChannelPrefix+"*", seatHoldExpiredChannel)
+	defer pubsub.Close()
+
+	for {
+		msg, err := pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event models.SeatEvent
+		if strings.HasPrefix(msg.Channel, seatEventChannelPrefix) {
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Println("invalid seat event:", err)
+				continue
+			}
+		} else {
+			var scheduleID, seatID int
+			if _, err := fmt.Sscanf(msg.Payload, "seat_hold:%d:%d", &scheduleID, &seatID); err != nil {
+				// bukan key hold kursi, misalnya index
+				continue
+			}
+			event = models.NewSeatEvent(models.SeatEventReleased, scheduleID, []int{seatID})
+		}
+		sr.dispatch(event)
+	}
+}
+
+func (sr *SeatEventRepo) dispatch(event models.SeatEvent) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	for ch := range sr.subs[event.ScheduleID] {
+		select {
+		case ch <- event:
+		default:
+			// client yang lambat tidak boleh menahan client lain
+		}
+	}
+}
+
+// checkExpiredEvents memastikan redis mengirim notifikasi key expired.
+// CONFIG SET hanya dijalankan kalau REDIS_SET_KEYSPACE_EVENTS=true, selain itu cukup diberi peringatan.
+func checkExpiredEvents(ctx context.Context, rdb *redis.Client) {
+	cfg, err := rdb.ConfigGet(ctx, "notify-keyspace-events").Result()
+	if err != nil {
+		log.Println("cannot read redis notify-keyspace-events, expired seat holds need \"Ex\" to be announced:", err)
+		return
+	}
+	flags := cfg["notify-keyspace-events"]
+	hasExpired := strings.Contains(flags, "x") || strings.Contains(flags, "A")
+	if strings.Contains(flags, "E") && hasExpired {
+		return
+	}
+
+	if os.Getenv("REDIS_SET_KEYSPACE_EVENTS") != "true" {
+		log.Printf("redis notify-keyspace-events is %q, expired seat holds are not announced until it includes \"Ex\"\n", flags)
+		return
+	}
+	if !strings.Contains(flags, "E") {
+		flags += "E"
+	}
+	if !hasExpired {
+		flags += "x"
+	}
+	if err := rdb.ConfigSet(ctx, "notify-keyspace-events", flags).Err(); err != nil {
+		log.Println("failed to enable redis expired events:", err)
+	}
+}
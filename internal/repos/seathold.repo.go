@@ -0,0 +1,280 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrSeatNotHeld   = errors.New("seat is not held by this user")
+	ErrSeatHoldLimit = errors.New("too many seats held for this schedule")
+)
+
+// SeatHeldError dikembalikan ketika sebagian kursi sedang di-hold user lain
+type SeatHeldError struct {
+	SeatIDs []int
+}
+
+func (e *SeatHeldError) Error() string {
+	return fmt.Sprintf("seats already held: %v", e.SeatIDs)
+}
+
+// hold semua kursi sekaligus, gagal kalau ada satu kursi yang dipegang user lain.
+// mengembalikan -1 kalau total kursi yang di-hold owner di schedule ini melebihi batas.
+// KEYS[1] = index set schedule, KEYS[2..] = key kursi
+// ARGV[1] = owner, ARGV[2] = ttl (ms), ARGV[3] = batas kursi per user, ARGV[4] = prefix key kursi, ARGV[5..] = seat id
+var holdSeatsScript = redis.NewScript(`
+local conflicts = {}
+local requested = {}
+for i = 2, #KEYS do
+	requested[ARGV[i + 3]] = true
+	local owner = redis.call('GET', KEYS[i])
+	if owner and owner ~= ARGV[1] then
+		table.insert(conflicts, ARGV[i + 3])
+	end
+end
+if #conflicts > 0 then
+	return conflicts
+end
+local held = #KEYS - 1
+for _, seat in ipairs(redis.call('SMEMBERS', KEYS[1])) do
+	if not requested[seat] and redis.call('GET', ARGV[4] .. seat) == ARGV[1] then
+		held = held + 1
+	end
+end
+if held > tonumber(ARGV[3]) then
+	return -1
+end
+for i = 2, #KEYS do
+	redis.call('SET', KEYS[i], ARGV[1], 'PX', ARGV[2])
+	redis.call('SADD', KEYS[1], ARGV[i + 3])
+end
+if redis.call('PTTL', KEYS[1]) < tonumber(ARGV[2]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return conflicts
+`)
+
+// perpanjang ttl, hanya kalau semua kursi masih dipegang owner
+// KEYS[1] = index set schedule, KEYS[2..] = key kursi
+// ARGV[1] = owner, ARGV[2] = ttl (ms)
+var extendSeatsScript = redis.NewScript(`
+for i = 2, #KEYS do
+	if redis.call('GET', KEYS[i]) ~= ARGV[1] then
+		return 0
+	end
+end
+for i = 2, #KEYS do
+	redis.call('PEXPIRE', KEYS[i], ARGV[2])
+end
+if redis.call('PTTL', KEYS[1]) < tonumber(ARGV[2]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// lepas kursi milik owner saja
+// KEYS[1] = index set schedule, KEYS[2..] = key kursi
+// ARGV[1] = owner, ARGV[2..] = seat id
+var releaseSeatsScript = redis.NewScript(`
+local released = 0
+for i = 2, #KEYS do
+	if redis.call('GET', KEYS[i]) == ARGV[1] then
+		redis.call('DEL', KEYS[i])
+		redis.call('SREM', KEYS[1], ARGV[i])
+		released = released + 1
+	end
+end
+return released
+`)
+
+type SeatHoldRepo struct {
+	redis   *redis.Client
+	ttl     time.Duration
+	maxSeat int
+}
+
+func NewSeatHoldRepo(redis *redis.Client) *SeatHoldRepo {
+	ttl := 10 * time.Minute
+	if d, err := time.ParseDuration(os.Getenv("SEAT_HOLD_TTL")); err == nil && d > 0 {
+		ttl = d
+	}
+	// batas kursi yang boleh di-hold satu user per schedule
+	maxSeat := 10
+	if n, err := strconv.Atoi(os.Getenv("SEAT_HOLD_MAX")); err == nil && n > 0 {
+		maxSeat = n
+	}
+	return &SeatHoldRepo{redis: redis, ttl: ttl, maxSeat: maxSeat}
+}
+
+func (sr *SeatHoldRepo) TTL() time.Duration {
+	return sr.ttl
+}
+
+func (sr *SeatHoldRepo) MaxSeats() int {
+	return sr.maxSeat
+}
+
+func seatHoldKeyPrefix(scheduleID int) string {
+	return fmt.Sprintf("seat_hold:%d:", scheduleID)
+}
+
+func seatHoldKey(scheduleID, seatID int) string {
+	return fmt.Sprintf("%s%d", seatHoldKeyPrefix(scheduleID), seatID)
+}
+
+func seatHoldIndexKey(scheduleID int) string {
+	return fmt.Sprintf("seat_hold:%d:index", scheduleID)
+}
+
+func (sr *SeatHoldRepo) seatKeys(scheduleID int, seatIDs []int) ([]string, []any) {
+	keys := []string{seatHoldIndexKey(scheduleID)}
+	ids := make([]any, 0, len(seatIDs))
+	for _, id := range seatIDs {
+		keys = append(keys, seatHoldKey(scheduleID, id))
+		ids = append(ids, id)
+	}
+	return keys, ids
+}
+
+func (sr *SeatHoldRepo) HoldSeats(ctx context.Context, scheduleID int, seatIDs []int, userID uuid.UUID) (time.Time, error) {
+	keys, ids := sr.seatKeys(scheduleID, seatIDs)
+	args := append([]any{userID.String(), sr.ttl.Milliseconds(), sr.maxSeat, seatHoldKeyPrefix(scheduleID)}, ids...)
+
+	res, err := holdSeatsScript.Run(ctx, sr.redis, keys, args...).Result()
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch res := res.(type) {
+	case int64:
+		return time.Time{}, ErrSeatHoldLimit
+	case []any:
+		if len(res) > 0 {
+			conflicts := make([]string, 0, len(res))
+			for _, id := range res {
+				conflicts = append(conflicts, fmt.Sprint(id))
+			}
+			return time.Time{}, &SeatHeldError{SeatIDs: atoiAll(conflicts)}
+		}
+		return time.Now().Add(sr.ttl), nil
+	}
+	return time.Time{}, fmt.Errorf("unexpected hold seats result %T", res)
+}
+
+func (sr *SeatHoldRepo) ExtendHolds(ctx context.Context, scheduleID int, seatIDs []int, userID uuid.UUID) (time.Time, error) {
+	keys, _ := sr.seatKeys(scheduleID, seatIDs)
+
+	ok, err := extendSeatsScript.Run(ctx, sr.redis, keys, userID.String(), sr.ttl.Milliseconds()).Int()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if ok == 0 {
+		return time.Time{}, ErrSeatNotHeld
+	}
+	return time.Now().Add(sr.ttl), nil
+}
+
+func (sr *SeatHoldRepo) ReleaseHolds(ctx context.Context, scheduleID int, seatIDs []int, userID uuid.UUID) error {
+	keys, ids := sr.seatKeys(scheduleID, seatIDs)
+	args := append([]any{userID.String()}, ids...)
+	return releaseSeatsScript.Run(ctx, sr.redis, keys, args...).Err()
+}
+
+// ClearHolds menghapus semua hold schedule, dipakai ketika schedule dibatalkan.
+// mengembalikan kursi yang tadinya di-hold.
+func (sr *SeatHoldRepo) ClearHolds(ctx context.Context, scheduleID int) ([]int, error) {
+	members, err := sr.redis.SMembers(ctx, seatHoldIndexKey(scheduleID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	seatIDs := atoiAll(members)
+	keys, _ := sr.seatKeys(scheduleID, seatIDs)
+	if err := sr.redis.Del(ctx, keys...).Err(); err != nil {
+		return nil, err
+	}
+	return seatIDs, nil
+}
+
+// VerifyHolds memastikan semua kursi masih di-hold oleh user
+func (sr *SeatHoldRepo) VerifyHolds(ctx context.Context, scheduleID int, seatIDs []int, userID uuid.UUID) error {
+	if len(seatIDs) == 0 {
+		return ErrSeatNotHeld
+	}
+	keys, _ := sr.seatKeys(scheduleID, seatIDs)
+
+	owners, err := sr.redis.MGet(ctx, keys[1:]...).Result()
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		if s, ok := owner.(string); !ok || s != userID.String() {
+			return ErrSeatNotHeld
+		}
+	}
+	return nil
+}
+
+// GetHeldSeats mengembalikan seat id -> owner untuk hold yang masih aktif.
+// hold yang sudah expired dibersihkan dari index.
+func (sr *SeatHoldRepo) GetHeldSeats(ctx context.Context, scheduleID int) (map[int]uuid.UUID, error) {
+	indexKey := seatHoldIndexKey(scheduleID)
+	members, err := sr.redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	held := make(map[int]uuid.UUID)
+	if len(members) == 0 {
+		return held, nil
+	}
+
+	seatIDs := atoiAll(members)
+	keys := make([]string, 0, len(seatIDs))
+	for _, id := range seatIDs {
+		keys = append(keys, seatHoldKey(scheduleID, id))
+	}
+
+	owners, err := sr.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []any
+	for i, owner := range owners {
+		s, ok := owner.(string)
+		if !ok {
+			expired = append(expired, seatIDs[i])
+			continue
+		}
+		ownerID, err := uuid.Parse(s)
+		if err != nil {
+			continue
+		}
+		held[seatIDs[i]] = ownerID
+	}
+
+	if len(expired) > 0 {
+		if err := sr.redis.SRem(ctx, indexKey, expired...).Err(); err != nil {
+			log.Println("failed to clean seat hold index:", err)
+		}
+	}
+	return held, nil
+}
+
+func atoiAll(values []string) []int {
+	out := make([]int, 0, len(values))
+	for _, v := range values {
+		if n, err := strconv.Atoi(v); err == nil {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package repos
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrStaffNotFound      = errors.New("staff not found")
+	ErrStaffUnassigned    = errors.New("staff is not assigned to a cinema")
+	ErrStaffCinemaUnknown = errors.New("cinema not found")
+)
+
+type StaffRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewStaffRepo(db *pgxpool.Pool) *StaffRepo {
+	return &StaffRepo{db: db}
+}
+
+// GetCinemaID mengembalikan cinema tempat staff bertugas
+func (sr *StaffRepo) GetCinemaID(ctx context.Context, userID uuid.UUID) (int, error) {
+	var cinemaID *int
+	err := sr.db.QueryRow(ctx, `SELECT cinemas_id FROM users WHERE id = $1 AND role = $2`, userID, models.RoleStaff).Scan(&cinemaID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrStaffNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	if cinemaID == nil {
+		return 0, ErrStaffUnassigned
+	}
+	return *cinemaID, nil
+}
+
+// AssignCinema menugaskan user ke cinema sebagai staff. akun user terdaftar sekaligus dinaikkan jadi staff,
+// akun admin dan akun tamu tidak bisa ditugaskan
+func (sr *StaffRepo) AssignCinema(ctx context.Context, userID uuid.UUID, cinemaID int) error {
+	tag, err := sr.db.Exec(ctx, `
+		UPDATE users SET role = $3, cinemas_id = $1, updated_at = NOW()
+		WHERE id = $2 AND (role = $3 OR (role = $4 AND password <> ''))
+	`, cinemaID, userID, models.RoleStaff, models.RoleUser)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return ErrStaffCinemaUnknown
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStaffNotFound
+	}
+	return nil
+}
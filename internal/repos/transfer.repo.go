@@ -0,0 +1,357 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrTransferNotFound   = errors.New("transfer not found")
+	ErrTransferRecipient  = errors.New("recipient is not a registered user")
+	ErrTransferSelf       = errors.New("cannot transfer an order to yourself")
+	ErrTransferPending    = errors.New("order already has a pending transfer")
+	ErrTransferNotAllowed = errors.New("only paid orders can be transferred")
+	ErrTransferSeats      = errors.New("seats are not part of this order")
+	ErrTransferClosed     = errors.New("transfer has already been answered")
+)
+
+type TransferRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewTransferRepo(db *pgxpool.Pool) *TransferRepo {
+	return &TransferRepo{db: db}
+}
+
+const transferSelect = `
+		SELECT t.id, t.orders_id, t.new_orders_id, t.from_users_id, fu.email, t.to_users_id, tu.email,
+		       COALESCE(ARRAY(
+		           SELECT se.seat_code FROM order_transfer_seats ts
+		           JOIN seats se ON se.id = ts.seats_id
+		           WHERE ts.order_transfers_id = t.id ORDER BY se.id
+		       ), '{}'),
+		       t.status, t.created_at, t.responded_at
+		FROM order_transfers t
+		JOIN users fu ON fu.id = t.from_users_id
+		JOIN users tu ON tu.id = t.to_users_id
+`
+
+func scanTransfer(row pgx.Row) (*models.OrderTransfer, error) {
+	var t models.OrderTransfer
+	err := row.Scan(&t.ID, &t.OrderID, &t.NewOrderID, &t.FromUserID, &t.FromEmail, &t.ToUserID, &t.ToEmail,
+		&t.SeatCodes, &t.Status, &t.CreatedAt, &t.RespondedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func queryTransfers(ctx context.Context, q querier, where string, args ...any) ([]models.OrderTransfer, error) {
+	rows, err := q.Query(ctx, transferSelect+where+` ORDER BY t.created_at DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := []models.OrderTransfer{}
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, *t)
+	}
+	return transfers, rows.Err()
+}
+
+// GetTransfers mengembalikan transfer yang dikirim maupun diterima user
+func (tr *TransferRepo) GetTransfers(ctx context.Context, userID uuid.UUID) ([]models.OrderTransfer, error) {
+	return queryTransfers(ctx, tr.db, `WHERE t.from_users_id = $1 OR t.to_users_id = $1`, userID)
+}
+
+// CreateTransfer menawarkan kursi order ke user lain lewat email-nya, seatCodes kosong berarti seluruh kursi
+func (tr *TransferRepo) CreateTransfer(ctx context.Context, orderID int, fromUserID uuid.UUID, toEmail string, seatCodes []string) (*models.OrderTransfer, error) {
+	tx, err := tr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		owner  uuid.UUID
+		status models.OrderStatus
+	)
+	err = tx.QueryRow(ctx, `SELECT users_id, status FROM orders WHERE id = $1 FOR UPDATE`, orderID).Scan(&owner, &status)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && owner != fromUserID) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status != models.OrderPaid {
+		return nil, ErrTransferNotAllowed
+	}
+
+	var toUserID uuid.UUID
+	// penerima harus akun user terdaftar, akun tamu (tanpa password), staff dan admin tidak bisa menerima tiket
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM users
+		WHERE LOWER(email) = LOWER($1) AND role = $2 AND password <> ''
+	`, strings.TrimSpace(toEmail), models.RoleUser).Scan(&toUserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTransferRecipient
+	}
+	if err != nil {
+		return nil, err
+	}
+	if toUserID == fromUserID {
+		return nil, ErrTransferSelf
+	}
+
+	seatIDs, err := orderSeatIDsByCodes(ctx, tx, orderID, seatCodes)
+	if err != nil {
+		return nil, err
+	}
+	if len(seatIDs) == 0 || (len(seatCodes) > 0 && len(seatIDs) != len(seatCodes)) {
+		return nil, ErrTransferSeats
+	}
+
+	var id int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO order_transfers (orders_id, from_users_id, to_users_id)
+		VALUES ($1,$2,$3)
+		RETURNING id
+	`, orderID, fromUserID, toUserID).Scan(&id)
+	if isUniqueViolation(err) {
+		return nil, ErrTransferPending
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, seatID := range seatIDs {
+		if _, err := tx.Exec(ctx, `INSERT INTO order_transfer_seats (order_transfers_id, seats_id) VALUES ($1,$2)`, id, seatID); err != nil {
+			return nil, err
+		}
+	}
+
+	transfer, err := scanTransfer(tx.QueryRow(ctx, transferSelect+`WHERE t.id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// orderSeatIDsByCodes mengambil kursi order, seatCodes kosong berarti seluruh kursi order
+func orderSeatIDsByCodes(ctx context.Context, tx pgx.Tx, orderID int, seatCodes []string) ([]int, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT os.seats_id
+		FROM order_seats os
+		JOIN seats se ON se.id = os.seats_id
+		WHERE os.orders_id = $1 AND (cardinality($2::text[]) = 0 OR se.seat_code = ANY($2))
+		ORDER BY os.seats_id
+	`, orderID, seatCodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// AcceptTransfer memindahkan kursi transfer ke order baru milik penerima.
+// order asal tetap milik pengirim (pembayaran, refund dan poin tetap tercatat di sana)
+// dan token tiket kedua order diterbitkan ulang supaya QR lama tidak berlaku lagi.
+// order asal yang tidak punya kursi lagi ditutup dengan status transferred tanpa token baru.
+func (tr *TransferRepo) AcceptTransfer(ctx context.Context, transferID int, userID uuid.UUID) (*models.OrderTransfer, error) {
+	tx, err := tr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	t, err := lockTransfer(ctx, tx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if t.ToUserID != userID {
+		return nil, ErrTransferNotFound
+	}
+	if t.Status != models.TransferPending {
+		return nil, ErrTransferClosed
+	}
+
+	var (
+		owner  uuid.UUID
+		status models.OrderStatus
+	)
+	if err := tx.QueryRow(ctx, `SELECT users_id, status FROM orders WHERE id = $1 FOR UPDATE`, t.OrderID).Scan(&owner, &status); err != nil {
+		return nil, err
+	}
+	if status != models.OrderPaid || owner != t.FromUserID {
+		// order sudah dibatalkan / dipakai sejak transfer dibuat
+		if err := answerTransfer(ctx, tx, t.ID, models.TransferCancelled, nil); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		return nil, ErrTransferNotAllowed
+	}
+
+	// kursi yang dipindah membawa harga tiketnya, nilai bayar tetap di order asal.
+	// token tiket butuh id order, jadi diisi placeholder unik dulu
+	var newOrderID int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO orders (qr_code, users_id, schedules_id, payments_id, fullname, email, phone_number,
+		                    subtotal, fee, total, status, paid_at, created_at)
+		SELECT $5, u.id, o.schedules_id, o.payments_id,
+		       COALESCE(NULLIF(TRIM(CONCAT(p.firstname, ' ', p.lastname)), ''), u.email), u.email,
+		       COALESCE(p.phone_number, ''),
+		       (SELECT COALESCE(SUM(os.price), 0) FROM order_seats os
+		        JOIN order_transfer_seats ts ON ts.seats_id = os.seats_id AND ts.order_transfers_id = $3
+		        WHERE os.orders_id = o.id),
+		       0, 0, $4, o.paid_at, NOW()
+		FROM orders o
+		JOIN users u ON u.id = $2
+		LEFT JOIN profile p ON p.user_id = u.id
+		WHERE o.id = $1
+		RETURNING id
+	`, t.OrderID, userID, t.ID, models.OrderPaid, "PENDING-"+uuid.NewString()).Scan(&newOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE order_seats SET orders_id = $1
+		WHERE orders_id = $2 AND seats_id IN (SELECT seats_id FROM order_transfer_seats WHERE order_transfers_id = $3)
+	`, newOrderID, t.OrderID, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if int(tag.RowsAffected()) != len(t.SeatCodes) {
+		return nil, ErrTransferSeats
+	}
+
+	var remaining int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM order_seats WHERE orders_id = $1`, t.OrderID).Scan(&remaining); err != nil {
+		return nil, err
+	}
+	if remaining == 0 {
+		if _, err := transitionOrder(ctx, tx, t.OrderID, models.OrderTransferred); err != nil {
+			return nil, err
+		}
+	} else if _, err := issueTicketToken(ctx, tx, t.OrderID); err != nil {
+		return nil, err
+	}
+	if _, err := issueTicketToken(ctx, tx, newOrderID); err != nil {
+		return nil, err
+	}
+
+	if err := answerTransfer(ctx, tx, t.ID, models.TransferAccepted, &newOrderID); err != nil {
+		return nil, err
+	}
+	accepted, err := scanTransfer(tx.QueryRow(ctx, transferSelect+`WHERE t.id = $1`, t.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return accepted, nil
+}
+
+// DeclineTransfer dipakai penerima, CancelTransfer dipakai pengirim
+func (tr *TransferRepo) DeclineTransfer(ctx context.Context, transferID int, userID uuid.UUID) (*models.OrderTransfer, error) {
+	return tr.closeTransfer(ctx, transferID, userID, models.TransferDeclined)
+}
+
+func (tr *TransferRepo) CancelTransfer(ctx context.Context, transferID int, userID uuid.UUID) (*models.OrderTransfer, error) {
+	return tr.closeTransfer(ctx, transferID, userID, models.TransferCancelled)
+}
+
+func (tr *TransferRepo) closeTransfer(ctx context.Context, transferID int, userID uuid.UUID, to models.TransferStatus) (*models.OrderTransfer, error) {
+	tx, err := tr.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	t, err := lockTransfer(ctx, tx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if (to == models.TransferDeclined && t.ToUserID != userID) || (to == models.TransferCancelled && t.FromUserID != userID) {
+		return nil, ErrTransferNotFound
+	}
+	if t.Status != models.TransferPending {
+		return nil, ErrTransferClosed
+	}
+
+	if err := answerTransfer(ctx, tx, t.ID, to, nil); err != nil {
+		return nil, err
+	}
+	closed, err := scanTransfer(tx.QueryRow(ctx, transferSelect+`WHERE t.id = $1`, t.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return closed, nil
+}
+
+func lockTransfer(ctx context.Context, tx pgx.Tx, transferID int) (*models.OrderTransfer, error) {
+	return scanTransfer(tx.QueryRow(ctx, transferSelect+`WHERE t.id = $1 FOR UPDATE OF t`, transferID))
+}
+
+func answerTransfer(ctx context.Context, tx pgx.Tx, transferID int, status models.TransferStatus, newOrderID *int) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE order_transfers SET status = $1, new_orders_id = $2, responded_at = NOW()
+		WHERE id = $3
+	`, status, newOrderID, transferID)
+	return err
+}
+
+// attachTransfers mengisi riwayat transfer order, baik sebagai order asal maupun order hasil transfer
+func attachTransfers(ctx context.Context, q querier, details []models.OrderDetail) error {
+	if len(details) == 0 {
+		return nil
+	}
+	ids := make([]int, 0, len(details))
+	for _, d := range details {
+		ids = append(ids, d.ID)
+	}
+
+	transfers, err := queryTransfers(ctx, q, `WHERE t.orders_id = ANY($1) OR t.new_orders_id = ANY($1)`, ids)
+	if err != nil {
+		return err
+	}
+	for i := range details {
+		for _, t := range transfers {
+			if t.OrderID == details[i].ID || (t.NewOrderID != nil && *t.NewOrderID == details[i].ID) {
+				details[i].Transfers = append(details[i].Transfers, t)
+			}
+		}
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 func initAdminRouter(router *gin.Engine, db *pgxpool.Pool) {
 	repo := repos.NewAdminRepo(db)
 	handler := handlers.NewAdminHandler(repo)
+	promoHandler := handlers.NewPromoHandler(repos.NewPromoRepo(db))
+	hallHandler := handlers.NewHallHandler(repos.NewHallRepo(db))
 
 	admin := router.Group("/admin", middlewares.RequiredToken, middlewares.Access("admin"))
 
@@ -19,4 +21,21 @@ func initAdminRouter(router *gin.Engine, db *pgxpool.Pool) {
 	admin.GET("/movies/:id", handler.GetMovieByID)
 	admin.PATCH("/movies/:id", handler.UpdateMovie)
 	admin.DELETE("/movies/:id", handler.DeleteMovie)
+
+	admin.GET("/prices", handler.GetPriceOverrides)
+	admin.POST("/prices", handler.CreatePriceOverride)
+	admin.DELETE("/prices/:id", handler.DeletePriceOverride)
+
+	admin.GET("/promos", promoHandler.GetPromos)
+	admin.POST("/promos", promoHandler.CreatePromo)
+	admin.GET("/promos/:id", promoHandler.GetPromo)
+	admin.PATCH("/promos/:id", promoHandler.UpdatePromo)
+	admin.DELETE("/promos/:id", promoHandler.DeletePromo)
+
+	admin.GET("/halls", hallHandler.GetHalls)
+	admin.POST("/halls", hallHandler.CreateHall)
+	admin.GET("/halls/:id", hallHandler.GetHall)
+	admin.PATCH("/halls/:id", hallHandler.UpdateHall)
+	admin.DELETE("/halls/:id", hallHandler.DeleteHall)
+	admin.PUT("/halls/:id/blocked-seats", hallHandler.SetBlockedSeats)
 }
@@ -0,0 +1,33 @@
+package routers
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/handlers"
+	"github.com/Darari17/be-tickitz/internal/middlewares"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func initGuestRouter(router *gin.Engine, db *pgxpool.Pool, redis *redis.Client) {
+	guestHandler := handlers.NewGuestHandler(repos.NewAuthRepo(db), repos.NewOrderRepo(db))
+	rateLimitRepo := repos.NewRateLimitRepo(redis)
+
+	guestGroup := router.Group("/guest")
+	// setiap token membuat satu akun tamu, jadi dibatasi per IP
+	guestGroup.POST("/token", middlewares.RateLimit(rateLimitRepo, "guest_token", guestTokenLimit(), time.Hour), guestHandler.CreateGuestToken)
+	guestGroup.GET("/orders", guestHandler.GetGuestOrder)
+	guestGroup.POST("/claim", middlewares.RequiredToken, middlewares.Access("user"), guestHandler.ClaimGuestOrders)
+}
+
+// guestTokenLimit adalah jumlah token tamu per IP per jam (GUEST_TOKEN_LIMIT), default 10
+func guestTokenLimit() int {
+	if n, err := strconv.Atoi(os.Getenv("GUEST_TOKEN_LIMIT")); err == nil && n > 0 {
+		return n
+	}
+	return 10
+}
@@ -2,20 +2,46 @@ package routers
 
 import (
 	"github.com/Darari17/be-tickitz/internal/handlers"
+	"github.com/Darari17/be-tickitz/internal/mailer"
 	"github.com/Darari17/be-tickitz/internal/middlewares"
+	"github.com/Darari17/be-tickitz/internal/payments"
 	"github.com/Darari17/be-tickitz/internal/repos"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 )
 
-func initOrderRouter(router *gin.Engine, db *pgxpool.Pool) {
+func initOrderRouter(router *gin.Engine, db *pgxpool.Pool, redis *redis.Client, providers *payments.Registry, m mailer.Mailer, seatEvents *repos.SeatEventRepo) {
 	orderRepo := repos.NewOrderRepo(db)
-	orderHandler := handlers.NewOrderHandler(orderRepo)
+	seatHoldRepo := repos.NewSeatHoldRepo(redis)
+	paymentRepo := repos.NewPaymentRepo(db)
+	orderHandler := handlers.NewOrderHandler(orderRepo, seatHoldRepo, seatEvents, paymentRepo, providers, m)
+	idempotencyRepo := repos.NewIdempotencyRepo(redis)
+	transferHandler := handlers.NewTransferHandler(repos.NewTransferRepo(db))
 
-	orderGroup := router.Group("/orders", middlewares.RequiredToken, middlewares.Access("admin", "user"))
-	orderGroup.POST("", orderHandler.CreateOrder)
+	// akun tamu (general) bisa memesan tanpa registrasi
+	orderGroup := router.Group("/orders", middlewares.RequiredToken, middlewares.Access("admin", "user", "general"))
+	orderGroup.POST("", middlewares.Idempotency(idempotencyRepo), orderHandler.CreateOrder)
+	orderGroup.POST("/quote", orderHandler.QuoteOrder)
 	orderGroup.GET("/history", orderHandler.GetOrderHistory)
+	orderGroup.GET("/transfers", transferHandler.GetTransfers)
+	orderGroup.POST("/transfers/:id/accept", transferHandler.AcceptTransfer)
+	orderGroup.POST("/transfers/:id/decline", transferHandler.DeclineTransfer)
+	orderGroup.POST("/transfers/:id/cancel", transferHandler.CancelTransfer)
 	orderGroup.GET("/schedules", orderHandler.GetSchedules)
 	orderGroup.GET("/seats", orderHandler.GetAvailableSeats)
+	orderGroup.GET("/seats/map", orderHandler.GetSeatMap)
+	orderGroup.POST("/holds", orderHandler.HoldSeats)
+	orderGroup.PATCH("/holds", orderHandler.ExtendSeatHolds)
+	orderGroup.DELETE("/holds", orderHandler.ReleaseSeatHolds)
 	orderGroup.GET("/:id", orderHandler.GetTransactionDetail)
+	orderGroup.POST("/:id/pay", middlewares.Access("admin"), orderHandler.ConfirmPayment)
+	orderGroup.POST("/:id/cancel", orderHandler.CancelOrder)
+	orderGroup.GET("/:id/qr", orderHandler.GetTicketQR)
+	orderGroup.POST("/:id/qr/reissue", orderHandler.ReissueTicketQR)
+	orderGroup.GET("/:id/ticket.pdf", orderHandler.GetTicketPDF)
+	orderGroup.POST("/:id/transfers", transferHandler.CreateTransfer)
+
+	adminOrderGroup := router.Group("/admin/orders", middlewares.RequiredToken, middlewares.Access("admin"))
+	adminOrderGroup.POST("/:id/refund", orderHandler.RefundOrder)
 }
@@ -0,0 +1,23 @@
+package routers
+
+import (
+	"github.com/Darari17/be-tickitz/internal/handlers"
+	"github.com/Darari17/be-tickitz/internal/mailer"
+	"github.com/Darari17/be-tickitz/internal/middlewares"
+	"github.com/Darari17/be-tickitz/internal/payments"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initPaymentRouter(router *gin.Engine, db *pgxpool.Pool, providers *payments.Registry, m mailer.Mailer, seatEvents *repos.SeatEventRepo) {
+	paymentRepo := repos.NewPaymentRepo(db)
+	paymentHandler := handlers.NewPaymentHandler(paymentRepo, repos.NewOrderRepo(db), seatEvents, providers, m)
+
+	paymentGroup := router.Group("/payments")
+	paymentGroup.POST("/webhook/:provider", paymentHandler.Webhook)
+
+	adminRefundGroup := router.Group("/admin/refunds", middlewares.RequiredToken, middlewares.Access("admin"))
+	adminRefundGroup.GET("", paymentHandler.GetRefunds)
+	adminRefundGroup.POST("/:id/retry", paymentHandler.RetryRefund)
+}
@@ -14,6 +14,7 @@ func initProfileRouter(router *gin.Engine, db *pgxpool.Pool) {
 
 	profile := router.Group("/profile", middlewares.RequiredToken, middlewares.Access("user"))
 	profile.GET("", profileHandler.GetProfile)
+	profile.GET("/points", profileHandler.GetPoints)
 	profile.PATCH("", profileHandler.UpdateProfile)
 	profile.PATCH("/change-password", profileHandler.ChangePassword)
 }
@@ -8,20 +8,30 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	docs "github.com/Darari17/be-tickitz/docs"
+	"github.com/Darari17/be-tickitz/internal/mailer"
 	"github.com/Darari17/be-tickitz/internal/middlewares"
+	"github.com/Darari17/be-tickitz/internal/payments"
+	"github.com/Darari17/be-tickitz/internal/repos"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func InitRouter(db *pgxpool.Pool, redis *redis.Client) *gin.Engine {
+func InitRouter(db *pgxpool.Pool, redis *redis.Client, providers *payments.Registry, m mailer.Mailer) *gin.Engine {
 	router := gin.Default()
 	router.Use(middlewares.CORSMiddleware)
 
+	// satu hub event kursi per instance, dipakai bersama oleh semua router
+	seatEvents := repos.NewSeatEventRepo(redis)
+
 	initAuthRouter(router, db)
+	initGuestRouter(router, db, redis)
 	initMovieRouter(router, db, redis)
-	initOrderRouter(router, db)
+	initOrderRouter(router, db, redis, providers, m, seatEvents)
+	initPaymentRouter(router, db, providers, m, seatEvents)
+	initScheduleRouter(router, db, redis, providers, seatEvents)
 	initProfileRouter(router, db)
 	initAdminRouter(router, db)
+	initStaffRouter(router, db)
 
 	router.Static("/img", "public")
 
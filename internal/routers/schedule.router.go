@@ -0,0 +1,29 @@
+package routers
+
+import (
+	"github.com/Darari17/be-tickitz/internal/handlers"
+	"github.com/Darari17/be-tickitz/internal/middlewares"
+	"github.com/Darari17/be-tickitz/internal/payments"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+func initScheduleRouter(router *gin.Engine, db *pgxpool.Pool, redis *redis.Client, providers *payments.Registry, seatEvents *repos.SeatEventRepo) {
+	scheduleHandler := handlers.NewScheduleHandler(repos.NewScheduleRepo(db), repos.NewOrderRepo(db), repos.NewSeatHoldRepo(redis), seatEvents, repos.NewPaymentRepo(db), providers)
+
+	scheduleGroup := router.Group("/schedules")
+	scheduleGroup.GET("", scheduleHandler.BrowseSchedules)
+	scheduleGroup.GET("/:id/seats/stream", scheduleHandler.StreamSeats)
+
+	adminScheduleGroup := router.Group("/admin/schedules", middlewares.RequiredToken, middlewares.Access("admin"))
+	adminScheduleGroup.GET("", scheduleHandler.GetSchedules)
+	adminScheduleGroup.POST("", scheduleHandler.CreateSchedule)
+	adminScheduleGroup.POST("/bulk", scheduleHandler.BulkCreateSchedules)
+	adminScheduleGroup.POST("/recurring", scheduleHandler.CreateRecurringSchedules)
+	adminScheduleGroup.GET("/:id", scheduleHandler.GetSchedule)
+	adminScheduleGroup.PATCH("/:id", scheduleHandler.UpdateSchedule)
+	adminScheduleGroup.POST("/:id/cancel", scheduleHandler.CancelSchedule)
+	adminScheduleGroup.DELETE("/:id", scheduleHandler.DeleteSchedule)
+}
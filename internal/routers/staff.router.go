@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"github.com/Darari17/be-tickitz/internal/handlers"
+	"github.com/Darari17/be-tickitz/internal/middlewares"
+	"github.com/Darari17/be-tickitz/internal/repos"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initStaffRouter(router *gin.Engine, db *pgxpool.Pool) {
+	checkInRepo := repos.NewCheckInRepo(db)
+	staffHandler := handlers.NewStaffHandler(checkInRepo, repos.NewStaffRepo(db))
+
+	staff := router.Group("/staff", middlewares.RequiredToken, middlewares.Access("staff", "admin"))
+	staff.POST("/checkin", staffHandler.CheckIn)
+
+	adminStaff := router.Group("/admin/staff", middlewares.RequiredToken, middlewares.Access("admin"))
+	adminStaff.PUT("/:id/cinema", staffHandler.AssignCinema)
+}
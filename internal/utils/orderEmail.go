@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	"github.com/Darari17/be-tickitz/internal/mailer"
+	"github.com/Darari17/be-tickitz/internal/models"
+)
+
+const orderEmailText = `Hi {{.FullName}},
+
+{{if .Ticket}}Your payment is complete. Here are your ticket details.{{else}}Thank you for your order at Tickitz. Please complete the payment to get your ticket.{{end}}
+
+Order     : #{{.ID}}
+Movie     : {{.Movie.Title}}
+Cinema    : {{.CinemaName}}, {{.Location}}
+Date      : {{.Date.Format "Monday, 02 January 2006"}}
+Time      : {{.TimeStr}}
+Seats     : {{seats .Seats}}
+Total     : {{.Total}}
+Payment   : {{.PaymentName}}
+Status    : {{.Status}}
+{{- if and .PaymentURL (not .Ticket)}}
+
+Complete your payment here: {{.PaymentURL}}
+{{- end}}
+{{- if and .PaymentInstructions (not .Ticket)}}
+
+{{.PaymentInstructions}}
+{{- end}}
+{{- if .OrderLink}}
+
+View your order any time here: {{.OrderLink}}
+Register with this email to keep the order in your account.
+{{- end}}
+{{- if .Ticket}}
+
+Show the QR code in this email or the e-ticket in the app at the cinema entrance.
+{{- else}}
+
+Your ticket will be sent to this email once the payment is completed.
+{{- end}}
+`
+
+const orderEmailHTML = `<!DOCTYPE html>
+<html>
+<body style="margin:0;padding:24px;background:#f5f6f8;font-family:Arial,Helvetica,sans-serif;color:#14142b">
+  <table width="100%" cellpadding="0" cellspacing="0" style="max-width:520px;margin:0 auto;background:#ffffff;border-radius:8px;overflow:hidden">
+    <tr><td style="background:#5f2eea;color:#ffffff;padding:16px 24px;font-size:22px;font-weight:bold">Tickitz</td></tr>
+    <tr><td style="padding:24px">
+      <p>Hi {{.FullName}},</p>
+      {{- if .Ticket}}
+      <p>Your payment is complete. Here are your ticket details.</p>
+      {{- else}}
+      <p>Thank you for your order. Please complete the payment to get your ticket.</p>
+      {{- end}}
+      <h2 style="margin:16px 0 8px">{{.Movie.Title}}</h2>
+      <table cellpadding="4" cellspacing="0" style="font-size:14px">
+        <tr><td style="color:#6e7191">Order</td><td><b>#{{.ID}}</b></td></tr>
+        <tr><td style="color:#6e7191">Cinema</td><td><b>{{.CinemaName}}, {{.Location}}</b></td></tr>
+        <tr><td style="color:#6e7191">Date</td><td><b>{{.Date.Format "Monday, 02 January 2006"}}</b></td></tr>
+        <tr><td style="color:#6e7191">Time</td><td><b>{{.TimeStr}}</b></td></tr>
+        <tr><td style="color:#6e7191">Seats</td><td><b>{{seats .Seats}}</b></td></tr>
+        <tr><td style="color:#6e7191">Total</td><td><b>{{.Total}}</b></td></tr>
+        <tr><td style="color:#6e7191">Payment</td><td><b>{{.PaymentName}}</b></td></tr>
+        <tr><td style="color:#6e7191">Status</td><td><b>{{.Status}}</b></td></tr>
+      </table>
+      {{- if and .PaymentURL (not .Ticket)}}
+      <p><a href="{{.PaymentURL}}" style="display:inline-block;background:#5f2eea;color:#ffffff;padding:10px 18px;border-radius:4px;text-decoration:none">Complete payment</a></p>
+      {{- end}}
+      {{- if and .PaymentInstructions (not .Ticket)}}
+      <p style="font-size:13px;color:#4e4b66">{{.PaymentInstructions}}</p>
+      {{- end}}
+      {{- if .OrderLink}}
+      <p style="font-size:13px;color:#4e4b66">You booked as a guest. <a href="{{.OrderLink}}" style="color:#5f2eea">View your order</a> any time, or register to keep it in your account.</p>
+      {{- end}}
+      {{- if .Ticket}}
+      <p style="text-align:center"><img src="cid:{{qr}}" width="240" height="240" alt="Ticket QR code"></p>
+      <p style="font-size:13px;color:#6e7191;text-align:center">Show this QR code at the cinema entrance.</p>
+      {{- else}}
+      <p style="font-size:13px;color:#6e7191;text-align:center">Your ticket will be sent to this email once the payment is completed.</p>
+      {{- end}}
+    </td></tr>
+  </table>
+</body>
+</html>
+`
+
+const orderEmailQRID = "ticket-qr@tickitz"
+
+var (
+	orderEmailFuncs = map[string]any{
+		"seats": seatCodes,
+		"qr":    func() string { return orderEmailQRID },
+	}
+	orderEmailTextTmpl = texttemplate.Must(texttemplate.New("order_text").Funcs(orderEmailFuncs).Parse(orderEmailText))
+	orderEmailHTMLTmpl = htmltemplate.Must(htmltemplate.New("order_html").Funcs(orderEmailFuncs).Parse(orderEmailHTML))
+)
+
+// orderEmailData menambahkan link order tamu dan penanda email tiket ke detail order untuk template
+type orderEmailData struct {
+	*models.OrderDetail
+	OrderLink string
+	Ticket    bool
+}
+
+// RenderOrderEmail membuat email order (text + html). dengan qrPNG email berisi tiket dan QR code
+// sebagai gambar inline, tanpa qrPNG hanya berisi instruksi pembayaran.
+// orderLink diisi untuk order tamu, kosong untuk order dari akun biasa.
+func RenderOrderEmail(d *models.OrderDetail, qrPNG []byte, orderLink string) (*mailer.Message, error) {
+	data := orderEmailData{OrderDetail: d, OrderLink: orderLink, Ticket: len(qrPNG) > 0}
+	var text, html bytes.Buffer
+	if err := orderEmailTextTmpl.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := orderEmailHTMLTmpl.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	msg := &mailer.Message{
+		To:      d.Email,
+		Subject: fmt.Sprintf("Complete your payment for Tickitz order #%d - %s", d.ID, d.Movie.Title),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}
+	if data.Ticket {
+		msg.Subject = fmt.Sprintf("Your Tickitz ticket #%d - %s", d.ID, d.Movie.Title)
+		msg.Inlines = []mailer.Inline{{
+			ContentID:   orderEmailQRID,
+			ContentType: "image/png",
+			Filename:    fmt.Sprintf("ticket-%d.png", d.ID),
+			Data:        qrPNG,
+		}}
+	}
+	return msg, nil
+}
@@ -0,0 +1,10 @@
+package utils
+
+import (
+	"github.com/skip2/go-qrcode"
+)
+
+// GenerateQRCode membuat gambar PNG dari isi QR code
+func GenerateQRCode(content string, size int) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, size)
+}
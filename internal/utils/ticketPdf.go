@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// RenderTicketPDF membuat e-ticket A5 dari detail transaksi dan gambar QR code (PNG)
+func RenderTicketPDF(d *models.OrderDetail, qrPNG []byte) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A5", "")
+	pdf.SetTitle(fmt.Sprintf("Tickitz E-Ticket #%d", d.ID), true)
+	pdf.SetMargins(12, 12, 12)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pageW, _ := pdf.GetPageSize()
+	contentW := pageW - 24
+
+	// header
+	pdf.SetFillColor(95, 46, 234)
+	pdf.Rect(0, 0, pageW, 22, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.SetXY(12, 6)
+	pdf.CellFormat(contentW/2, 10, "Tickitz", "", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(contentW/2, 10, fmt.Sprintf("Order #%d", d.ID), "", 0, "R", false, 0, "")
+
+	// poster dan judul
+	top := 30.0
+	textX := 12.0
+	if poster := posterPath(d.Movie.Poster); poster != "" {
+		pdf.ImageOptions(poster, 12, top, 32, 48, false, gofpdf.ImageOptions{ReadDpi: true}, 0, "")
+		if pdf.Err() {
+			// poster rusak atau formatnya tidak didukung, tiket tetap dibuat tanpa poster
+			pdf.ClearError()
+		} else {
+			textX = 50
+		}
+	}
+
+	pdf.SetTextColor(20, 20, 20)
+	pdf.SetFont("Helvetica", "B", 15)
+	pdf.SetXY(textX, top)
+	pdf.MultiCell(pageW-12-textX, 7, tr(d.Movie.Title), "", "L", false)
+
+	rows := [][2]string{
+		{"Cinema", d.CinemaName},
+		{"Location", d.Location},
+		// StartsAt sudah dalam zona waktu cinema, jadi tanggal dan jam sesuai jam tayang setempat
+		{"Date", d.StartsAt.Format("Monday, 02 January 2006")},
+		{"Time", d.StartsAt.Format("15:04 MST")},
+		{"Seats", seatCodes(d.Seats)},
+	}
+	pdf.SetY(pdf.GetY() + 2)
+	for _, row := range rows {
+		pdf.SetX(textX)
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(22, 6, row[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetTextColor(20, 20, 20)
+		pdf.MultiCell(pageW-12-textX-22, 6, tr(row[1]), "", "L", false)
+	}
+
+	// garis sobek
+	y := 92.0
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetDashPattern([]float64{2, 2}, 0)
+	pdf.Line(12, y, pageW-12, y)
+	pdf.SetDashPattern([]float64{}, 0)
+
+	// QR code
+	if len(qrPNG) > 0 {
+		pdf.RegisterImageOptionsReader("qr", gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrPNG))
+		size := 70.0
+		pdf.ImageOptions("qr", (pageW-size)/2, y+6, size, size, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	}
+
+	pdf.SetY(y + 80)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.CellFormat(contentW, 5, "Show this QR code at the cinema entrance", "", 1, "C", false, 0, "")
+	pdf.CellFormat(contentW, 5, tr(fmt.Sprintf("%s - %s", d.FullName, d.Email)), "", 1, "C", false, 0, "")
+	pdf.CellFormat(contentW, 5, fmt.Sprintf("Paid with %s - Total %d", d.PaymentName, d.Total), "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// posterPath mengembalikan lokasi file poster di folder public kalau formatnya didukung gofpdf
+func posterPath(poster string) string {
+	if poster == "" {
+		return ""
+	}
+	ext := strings.ToLower(filepath.Ext(poster))
+	if ext != ".png" && ext != ".jpg" && ext != ".jpeg" {
+		return ""
+	}
+	location := filepath.Join("public", filepath.Base(poster))
+	if _, err := os.Stat(location); err != nil {
+		return ""
+	}
+	return location
+}
+
+func seatCodes(seats []models.Seat) string {
+	codes := make([]string, 0, len(seats))
+	for _, s := range seats {
+		codes = append(codes, s.SeatCode)
+	}
+	return strings.Join(codes, ", ")
+}
@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/repos"
+)
+
+// GuestCleanupWorker menghapus akun tamu yang tidak pernah dipakai memesan.
+// setiap POST /guest/token membuat satu baris users, tanpa worker ini tabel users terus membesar.
+type GuestCleanupWorker struct {
+	authRepo  *repos.AuthRepo
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewGuestCleanupWorker(ar *repos.AuthRepo) *GuestCleanupWorker {
+	interval := time.Hour
+	if d, err := time.ParseDuration(os.Getenv("GUEST_CLEANUP_INTERVAL")); err == nil && d > 0 {
+		interval = d
+	}
+	// harus lebih lama dari masa berlaku token tamu supaya akun yang masih dipakai tidak terhapus
+	retention := 24 * time.Hour
+	if d, err := time.ParseDuration(os.Getenv("GUEST_RETENTION")); err == nil && d > 0 {
+		retention = d
+	}
+	return &GuestCleanupWorker{authRepo: ar, interval: interval, retention: retention}
+}
+
+// Run berjalan sampai ctx selesai
+func (w *GuestCleanupWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := w.authRepo.DeleteStaleGuests(ctx, time.Now().Add(-w.retention))
+		if err != nil {
+			log.Println("DeleteStaleGuests error:", err)
+		} else if deleted > 0 {
+			log.Printf("%d unused guest accounts deleted\n", deleted)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
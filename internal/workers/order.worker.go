@@ -0,0 +1,73 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Darari17/be-tickitz/internal/models"
+	"github.com/Darari17/be-tickitz/internal/repos"
+)
+
+// jumlah order yang di-expire dalam satu transaksi
+const orderExpiryBatch = 100
+
+// OrderExpiryWorker meng-expire order yang tidak dibayar sampai payment_deadline lalu mengabarkan
+// kursinya kembali tersedia. aman dijalankan di banyak instance karena repo memakai advisory lock.
+type OrderExpiryWorker struct {
+	orderRepo  *repos.OrderRepo
+	seatEvents *repos.SeatEventRepo
+	interval   time.Duration
+}
+
+func NewOrderExpiryWorker(or *repos.OrderRepo, ser *repos.SeatEventRepo) *OrderExpiryWorker {
+	interval := time.Minute
+	if d, err := time.ParseDuration(os.Getenv("ORDER_EXPIRY_INTERVAL")); err == nil && d > 0 {
+		interval = d
+	}
+	return &OrderExpiryWorker{orderRepo: or, seatEvents: ser, interval: interval}
+}
+
+// Run berjalan sampai ctx selesai
+func (w *OrderExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.expireOrders(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *OrderExpiryWorker) expireOrders(ctx context.Context) {
+	for {
+		orders, err := w.orderRepo.ExpireOverdueOrders(ctx, time.Now(), orderExpiryBatch)
+		if err != nil {
+			log.Println("ExpireOverdueOrders error:", err)
+			return
+		}
+
+		for _, order := range orders {
+			log.Printf("order %d expired, payment deadline passed\n", order.ID)
+			seatIDs, err := w.orderRepo.GetOrderSeatIDs(ctx, order.ID)
+			if err != nil {
+				log.Println("GetOrderSeatIDs error:", err)
+				continue
+			}
+			event := models.NewSeatEvent(models.SeatEventReleased, order.ScheduleID, seatIDs)
+			if err := w.seatEvents.Publish(ctx, event); err != nil {
+				log.Println("PublishSeatEvent error:", err)
+			}
+		}
+
+		// batch penuh berarti masih ada sisa order yang lewat batas
+		if len(orders) < orderExpiryBatch {
+			return
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTicket    = errors.New("invalid ticket token")
+	ErrInvalidOrderLink = errors.New("invalid or expired order link")
+)
+
+const (
+	ticketTokenPrefix    = "TKT1"
+	orderLinkTokenPrefix = "ORD1"
+)
+
+// GenTicketToken membuat token tiket untuk order dengan format
+// TKT1.<base64url(orderID:nonce)>.<base64url(hmac-sha256)>
+// nonce acak membuat token selalu unik meskipun order dibuat di detik yang sama
+func GenTicketToken(orderID int) (string, error) {
+	secret := os.Getenv("TICKET_SECRET")
+	if secret == "" {
+		return "", errors.New("no secret found")
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	payload := fmt.Sprintf("%d:%s", orderID, base64.RawURLEncoding.EncodeToString(nonce))
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return fmt.Sprintf("%s.%s.%s", ticketTokenPrefix, encoded, signTicket(secret, encoded)), nil
+}
+
+// VerifyTicketToken memeriksa signature token dan mengembalikan order id di dalamnya
+func VerifyTicketToken(token string) (int, error) {
+	secret := os.Getenv("TICKET_SECRET")
+	if secret == "" {
+		return 0, errors.New("no secret found")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != ticketTokenPrefix {
+		return 0, ErrInvalidTicket
+	}
+
+	// komparasi dengan waktu konstan
+	if !hmac.Equal([]byte(signTicket(secret, parts[1])), []byte(parts[2])) {
+		return 0, ErrInvalidTicket
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, ErrInvalidTicket
+	}
+	idStr, _, found := strings.Cut(string(payload), ":")
+	if !found {
+		return 0, ErrInvalidTicket
+	}
+	orderID, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, ErrInvalidTicket
+	}
+	return orderID, nil
+}
+
+// GenOrderLinkToken membuat token untuk link order tamu dengan format
+// ORD1.<base64url(orderID:expiredUnix)>.<base64url(hmac-sha256)>
+// prefix ikut ditandatangani supaya token tiket tidak bisa dipakai sebagai link dan sebaliknya
+func GenOrderLinkToken(orderID int, expiresAt time.Time) (string, error) {
+	secret := os.Getenv("TICKET_SECRET")
+	if secret == "" {
+		return "", errors.New("no secret found")
+	}
+
+	payload := fmt.Sprintf("%d:%d", orderID, expiresAt.Unix())
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return fmt.Sprintf("%s.%s.%s", orderLinkTokenPrefix, encoded, signTicket(secret, orderLinkTokenPrefix+"."+encoded)), nil
+}
+
+// VerifyOrderLinkToken memeriksa signature dan masa berlaku token link order tamu
+func VerifyOrderLinkToken(token string) (int, error) {
+	secret := os.Getenv("TICKET_SECRET")
+	if secret == "" {
+		return 0, errors.New("no secret found")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != orderLinkTokenPrefix {
+		return 0, ErrInvalidOrderLink
+	}
+	if !hmac.Equal([]byte(signTicket(secret, orderLinkTokenPrefix+"."+parts[1])), []byte(parts[2])) {
+		return 0, ErrInvalidOrderLink
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, ErrInvalidOrderLink
+	}
+	idStr, expStr, found := strings.Cut(string(payload), ":")
+	if !found {
+		return 0, ErrInvalidOrderLink
+	}
+	orderID, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, ErrInvalidOrderLink
+	}
+	exp, err := strconv.ParseInt(expStr, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return 0, ErrInvalidOrderLink
+	}
+	return orderID, nil
+}
+
+func signTicket(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,126 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTicketToken(t *testing.T) {
+	t.Setenv("TICKET_SECRET", "test-secret")
+
+	token, err := GenTicketToken(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenTicketToken(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == other {
+		t.Fatal("expected tokens for the same order to be unique")
+	}
+
+	orderID, err := VerifyTicketToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orderID != 42 {
+		t.Fatalf("expected order 42, got %d", orderID)
+	}
+
+	parts := strings.Split(token, ".")
+	forged := base64.RawURLEncoding.EncodeToString([]byte("43:nonce"))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"missing parts", parts[0] + "." + parts[1]},
+		{"wrong prefix", "TKT0." + parts[1] + "." + parts[2]},
+		{"tampered payload", parts[0] + "." + forged + "." + parts[2]},
+		{"tampered signature", parts[0] + "." + parts[1] + "." + strings.Repeat("A", len(parts[2]))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifyTicketToken(tt.token); !errors.Is(err, ErrInvalidTicket) {
+				t.Fatalf("expected ErrInvalidTicket, got %v", err)
+			}
+		})
+	}
+
+	t.Run("different secret", func(t *testing.T) {
+		t.Setenv("TICKET_SECRET", "another-secret")
+		if _, err := VerifyTicketToken(token); !errors.Is(err, ErrInvalidTicket) {
+			t.Fatalf("expected ErrInvalidTicket, got %v", err)
+		}
+	})
+}
+
+func TestTicketTokenWithoutSecret(t *testing.T) {
+	t.Setenv("TICKET_SECRET", "")
+
+	if _, err := GenTicketToken(1); err == nil {
+		t.Fatal("expected error without secret")
+	}
+	if _, err := VerifyTicketToken("TKT1.a.b"); err == nil {
+		t.Fatal("expected error without secret")
+	}
+}
+
+func TestOrderLinkToken(t *testing.T) {
+	t.Setenv("TICKET_SECRET", "test-secret")
+
+	token, err := GenOrderLinkToken(7, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderID, err := VerifyOrderLinkToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orderID != 7 {
+		t.Fatalf("expected order 7, got %d", orderID)
+	}
+
+	expired, err := GenOrderLinkToken(7, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticket, err := GenTicketToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(token, ".")
+	// perpanjang masa berlaku tanpa menandatangani ulang
+	extended := base64.RawURLEncoding.EncodeToString([]byte("7:9999999999"))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"expired", expired},
+		{"ticket token", ticket},
+		{"ticket prefix with link payload", "TKT1." + parts[1] + "." + parts[2]},
+		{"tampered expiry", parts[0] + "." + extended + "." + parts[2]},
+		{"tampered signature", parts[0] + "." + parts[1] + "." + strings.Repeat("A", len(parts[2]))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifyOrderLinkToken(tt.token); !errors.Is(err, ErrInvalidOrderLink) {
+				t.Fatalf("expected ErrInvalidOrderLink, got %v", err)
+			}
+		})
+	}
+
+	t.Run("link token as ticket", func(t *testing.T) {
+		if _, err := VerifyTicketToken(token); !errors.Is(err, ErrInvalidTicket) {
+			t.Fatalf("expected ErrInvalidTicket, got %v", err)
+		}
+	})
+}